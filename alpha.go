@@ -0,0 +1,190 @@
+package colorful
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// A ColorA is a color with an alpha channel. The color is stored straight,
+// i.e. not premultiplied, in the embedded Color, so that all the conversion
+// and distance methods of Color work on it as usual, and A is the opacity in
+// [0..1], 0 being fully transparent.
+type ColorA struct {
+	Color
+	A float64
+}
+
+// Implement the Go color.Color interface, which is alpha-premultiplied.
+func (col ColorA) RGBA() (r, g, b, a uint32) {
+	r = uint32(col.R*col.A*65535.0 + 0.5)
+	g = uint32(col.G*col.A*65535.0 + 0.5)
+	b = uint32(col.B*col.A*65535.0 + 0.5)
+	a = uint32(col.A*65535.0 + 0.5)
+	return
+}
+
+// MakeColorA constructs a colorful.ColorA from something implementing
+// color.Color, keeping the alpha. Unlike MakeColor, this can't fail: a fully
+// transparent color has no color, and becomes transparent black.
+func MakeColorA(col color.Color) ColorA {
+	r, g, b, a := col.RGBA()
+	if a == 0 {
+		return ColorA{}
+	}
+
+	// Since color.Color is alpha pre-multiplied, we need to divide the
+	// RGB values by alpha again in order to get back the original RGB.
+	fa := float64(a)
+	return ColorA{Color{float64(r) / fa, float64(g) / fa, float64(b) / fa}, fa / 65535.0}
+}
+
+// RGBA255 is like RGB255, plus the alpha, not premultiplied.
+func (col ColorA) RGBA255() (r, g, b, a uint8) {
+	r, g, b = col.RGB255()
+	a = uint8(col.A*255.0 + 0.5)
+	return
+}
+
+// Premultiplied returns the color premultiplied by its alpha, as used for
+// compositing.
+func (col ColorA) Premultiplied() (r, g, b, a float64) {
+	return col.R * col.A, col.G * col.A, col.B * col.A, col.A
+}
+
+// Premultiplied creates a ColorA from alpha-premultiplied values. If a is 0,
+// the result is transparent black.
+func Premultiplied(r, g, b, a float64) ColorA {
+	if a == 0 {
+		return ColorA{}
+	}
+	return ColorA{Color{r / a, g / a, b / a}, a}
+}
+
+// Checks whether the color exists in RGB space and its alpha is in [0..1].
+func (col ColorA) IsValid() bool {
+	return col.Color.IsValid() && 0.0 <= col.A && col.A <= 1.0
+}
+
+// Clamped clamps the color and the alpha into their valid range [0..1].
+func (col ColorA) Clamped() ColorA {
+	return ColorA{col.Color.Clamped(), clamp01(col.A)}
+}
+
+// Check for equality between colors within the tolerance Delta (1/255),
+// including the alpha.
+func (c1 ColorA) AlmostEqualRgba(c2 ColorA) bool {
+	return c1.AlmostEqualRgb(c2.Color) && math.Abs(c1.A-c2.A) < Delta
+}
+
+/// Hex ///
+///////////
+
+// Hex returns the hex "html" representation of the color including alpha, as in #ff008080.
+func (col ColorA) Hex() string {
+	r, g, b, a := col.RGBA255()
+	return fmt.Sprintf("#%02x%02x%02x%02x", r, g, b, a)
+}
+
+// HexA parses a "html" hex color-string with optional alpha, in the "#f0c",
+// "#f0c8", "#ff1034" or "#ff103480" form. Without alpha, the color is opaque.
+func HexA(scol string) (ColorA, error) {
+	var r, g, b, a uint8
+	format, factor, args := "", 1.0/255.0, []interface{}{&r, &g, &b}
+	switch len(scol) {
+	case 4:
+		format, factor, a = "#%1x%1x%1x", 1.0/15.0, 0xf
+	case 5:
+		format, factor = "#%1x%1x%1x%1x", 1.0/15.0
+		args = append(args, &a)
+	case 7:
+		format, a = "#%02x%02x%02x", 0xff
+	case 9:
+		format = "#%02x%02x%02x%02x"
+		args = append(args, &a)
+	default:
+		return ColorA{}, fmt.Errorf("color: %v is not a hex-color", scol)
+	}
+
+	n, err := fmt.Sscanf(scol, format, args...)
+	if err != nil {
+		return ColorA{}, err
+	}
+	if n != len(args) {
+		return ColorA{}, fmt.Errorf("color: %v is not a hex-color", scol)
+	}
+
+	return ColorA{Color{float64(r) * factor, float64(g) * factor, float64(b) * factor}, float64(a) * factor}, nil
+}
+
+/// Blending ///
+////////////////
+// Blending colors with alpha weighs each color by its alpha, like blending
+// premultiplied colors does, so that blending with a transparent color only
+// changes the alpha, instead of fading to a dark fringe. The alpha itself is
+// blended linearly.
+
+// blend blends the colors using the given blend method of Color. Weighing the
+// colors by alpha is the same as shifting t towards the more opaque color.
+func (c1 ColorA) blend(c2 ColorA, t float64, blend func(c1, c2 Color, t float64) Color) ColorA {
+	a := c1.A + t*(c2.A-c1.A)
+	if a == 0 {
+		return ColorA{blend(c1.Color, c2.Color, t), 0}
+	}
+	return ColorA{blend(c1.Color, c2.Color, t*c2.A/a), a}
+}
+
+// BlendRgb is like Color.BlendRgb, taking alpha into account.
+func (c1 ColorA) BlendRgb(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendRgb)
+}
+
+// BlendLinearRgb is like Color.BlendLinearRgb, taking alpha into account.
+func (c1 ColorA) BlendLinearRgb(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendLinearRgb)
+}
+
+// BlendHsv is like Color.BlendHsv, taking alpha into account.
+func (c1 ColorA) BlendHsv(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendHsv)
+}
+
+// BlendLab is like Color.BlendLab, taking alpha into account.
+func (c1 ColorA) BlendLab(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendLab)
+}
+
+// BlendLuv is like Color.BlendLuv, taking alpha into account.
+func (c1 ColorA) BlendLuv(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendLuv)
+}
+
+// BlendHcl is like Color.BlendHcl, taking alpha into account.
+func (c1 ColorA) BlendHcl(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendHcl)
+}
+
+// BlendLuvLCh is like Color.BlendLuvLCh, taking alpha into account.
+func (c1 ColorA) BlendLuvLCh(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendLuvLCh)
+}
+
+// BlendOkLab is like Color.BlendOkLab, taking alpha into account.
+func (c1 ColorA) BlendOkLab(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendOkLab)
+}
+
+// BlendOkLch is like Color.BlendOkLch, taking alpha into account.
+func (c1 ColorA) BlendOkLch(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendOkLch)
+}
+
+// BlendCam16Ucs is like Color.BlendCam16Ucs, taking alpha into account.
+func (c1 ColorA) BlendCam16Ucs(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendCam16Ucs)
+}
+
+// BlendPigment is like Color.BlendPigment, taking alpha into account.
+func (c1 ColorA) BlendPigment(c2 ColorA, t float64) ColorA {
+	return c1.blend(c2, t, Color.BlendPigment)
+}
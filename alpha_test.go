@@ -0,0 +1,119 @@
+package colorful
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestColorARGBA(t *testing.T) {
+	// Round-trips through the standard library's straight and premultiplied types.
+	for _, nrgba := range []color.NRGBA{{123, 45, 67, 255}, {123, 45, 67, 128}, {12, 34, 56, 200}, {0, 0, 0, 0}} {
+		c := MakeColorA(nrgba)
+		if got := color.NRGBAModel.Convert(c).(color.NRGBA); got != nrgba {
+			t.Errorf("MakeColorA(%v) => %v, converts back to %v", nrgba, c, got)
+		}
+		if r, g, b, a := c.RGBA255(); nrgba.A != 0 && (r != nrgba.R || g != nrgba.G || b != nrgba.B || a != nrgba.A) {
+			t.Errorf("MakeColorA(%v).RGBA255() => (%v, %v, %v, %v)", nrgba, r, g, b, a)
+		}
+	}
+
+	c := ColorA{Color{1.0, 0.5, 0.0}, 0.5}
+	rgba := color.RGBA64Model.Convert(c).(color.RGBA64)
+	if want := (color.RGBA64{32768, 16384, 0, 32768}); rgba != want {
+		t.Errorf("%v as RGBA64 => %v, want %v", c, rgba, want)
+	}
+	if c2 := MakeColorA(rgba); !c2.AlmostEqualRgba(c) {
+		t.Errorf("MakeColorA(%v) => %v, want %v", rgba, c2, c)
+	}
+
+	if c := MakeColorA(color.Transparent); c != (ColorA{}) {
+		t.Errorf("MakeColorA(color.Transparent) => %v, want transparent black", c)
+	}
+}
+
+func TestColorAPremultiplied(t *testing.T) {
+	c := ColorA{Color{0.8, 0.4, 0.2}, 0.5}
+	r, g, b, a := c.Premultiplied()
+	if r != 0.4 || g != 0.2 || b != 0.1 || a != 0.5 {
+		t.Errorf("%v.Premultiplied() => (%v, %v, %v, %v), want (0.4, 0.2, 0.1, 0.5)", c, r, g, b, a)
+	}
+	if c2 := Premultiplied(r, g, b, a); c2 != c {
+		t.Errorf("Premultiplied(%v, %v, %v, %v) => %v, want %v", r, g, b, a, c2, c)
+	}
+	if c2 := Premultiplied(0, 0, 0, 0); c2 != (ColorA{}) {
+		t.Errorf("Premultiplied(0, 0, 0, 0) => %v, want transparent black", c2)
+	}
+}
+
+func TestColorAHex(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want ColorA
+		out  string
+	}{
+		{"#ff0080", ColorA{Color{1.0, 0.0, 128.0 / 255.0}, 1.0}, "#ff0080ff"},
+		{"#ff008080", ColorA{Color{1.0, 0.0, 128.0 / 255.0}, 128.0 / 255.0}, "#ff008080"},
+		{"#f08", ColorA{Color{1.0, 0.0, 8.0 / 15.0}, 1.0}, "#ff0088ff"},
+		{"#f080", ColorA{Color{1.0, 0.0, 8.0 / 15.0}, 0.0}, "#ff008800"},
+	}
+	for _, tt := range tests {
+		c, err := HexA(tt.hex)
+		if err != nil || !c.AlmostEqualRgba(tt.want) {
+			t.Errorf("HexA(%v) => (%v, %v), want %v", tt.hex, c, err, tt.want)
+		}
+		if out := c.Hex(); out != tt.out {
+			t.Errorf("HexA(%v).Hex() => %v, want %v", tt.hex, out, tt.out)
+		}
+	}
+
+	for _, bad := range []string{"", "#ff0080f", "#ff00800", "ff008080", "#gg008080"} {
+		if _, err := HexA(bad); err == nil {
+			t.Errorf("HexA(%q) didn't fail", bad)
+		}
+	}
+}
+
+func TestColorAValid(t *testing.T) {
+	if (ColorA{Color{0.5, 0.5, 0.5}, 1.5}).IsValid() {
+		t.Errorf("Alpha 1.5 is valid")
+	}
+	c := ColorA{Color{1.1, -0.1, 0.5}, -0.2}
+	if want := (ColorA{Color{1.0, 0.0, 0.5}, 0.0}); c.Clamped() != want {
+		t.Errorf("%v.Clamped() => %v, want %v", c, c.Clamped(), want)
+	}
+}
+
+func TestColorABlend(t *testing.T) {
+	red := ColorA{Color{1, 0, 0}, 1}
+	blue := ColorA{Color{0, 0, 1}, 1}
+	clear := ColorA{Color{0, 0, 0}, 0}
+
+	// Opaque colors blend like without alpha.
+	if c, want := red.BlendLab(blue, 0.3), red.Color.BlendLab(blue.Color, 0.3); c.Color != want || c.A != 1 {
+		t.Errorf("%v.BlendLab(%v, 0.3) => %v, want %v", red, blue, c, want)
+	}
+
+	// Fading out keeps the color instead of darkening it.
+	blends := map[string]func(ColorA, ColorA, float64) ColorA{
+		"Rgb": ColorA.BlendRgb, "LinearRgb": ColorA.BlendLinearRgb, "Hsv": ColorA.BlendHsv,
+		"Lab": ColorA.BlendLab, "Luv": ColorA.BlendLuv, "Hcl": ColorA.BlendHcl,
+		"LuvLCh": ColorA.BlendLuvLCh, "OkLab": ColorA.BlendOkLab, "OkLch": ColorA.BlendOkLch,
+		"Cam16Ucs": ColorA.BlendCam16Ucs, "Pigment": ColorA.BlendPigment,
+	}
+	for name, blend := range blends {
+		c := blend(red, clear, 0.25)
+		if !c.Color.AlmostEqualRgb(red.Color) || math.Abs(c.A-0.75) > 1e-12 {
+			t.Errorf("%v.Blend%v(%v, 0.25) => %v, want %v with alpha 0.75", red, name, clear, c, red.Color)
+		}
+	}
+
+	// It is the same as blending premultiplied colors.
+	c1, c2 := ColorA{Color{0.8, 0.2, 0.4}, 0.8}, ColorA{Color{0.1, 0.6, 0.3}, 0.2}
+	r1, g1, b1, a1 := c1.Premultiplied()
+	r2, g2, b2, a2 := c2.Premultiplied()
+	want := Premultiplied(r1+0.4*(r2-r1), g1+0.4*(g2-g1), b1+0.4*(b2-b1), a1+0.4*(a2-a1))
+	if c := c1.BlendRgb(c2, 0.4); !c.AlmostEqualRgba(want) {
+		t.Errorf("%v.BlendRgb(%v, 0.4) => %v, want %v", c1, c2, c, want)
+	}
+}
@@ -0,0 +1,359 @@
+package colorful
+
+import "math"
+
+// Batch conversions convert whole slices of colors at once, which is much
+// faster than calling the conversion methods one by one in a loop for the
+// typical case of colors coming from 8-bit images or hex strings: their
+// components are multiples of 1/255, which are linearized with a lookup
+// table instead of math.Pow. Other colors are converted as usual. The results
+// are the same as those of the methods, up to floating point precision.
+//
+// The colors are processed in blocks which are stored as separate arrays
+// per channel, i.e. struct-of-arrays, and each stage of a conversion, such as
+// linear RGB to XYZ and XYZ to Lab, runs over a whole block before the next
+// one, which keeps the work tight and easy on the cache.
+//
+// Like copy, they all convert min(len(dst), len(src)) colors and return that
+// number. They only read src and write dst and keep no state, so they are
+// safe for concurrent use on disjoint slices, e.g. converting the rows of an
+// image in separate goroutines.
+
+// The number of colors converted per block.
+const batchSize = 64
+
+// linearizeLut holds the linearization of all multiples of 1/255.
+var linearizeLut = func() (lut [256]float64) {
+	for i := range lut {
+		lut[i] = linearize(float64(i) / 255.0)
+	}
+	return
+}()
+
+// linearize8 is linearize, but using linearizeLut if v is a multiple of 1/255.
+func linearize8(v float64) float64 {
+	f := v * 255.0
+	if i := int(f + 0.5); 0 <= i && i <= 255 && math.Abs(f-float64(i)) < 1e-9 {
+		return linearizeLut[i]
+	}
+	return linearize(v)
+}
+
+// A batchStage is one step of a conversion, converting the first n values of
+// a block in place, with one array per channel.
+type batchStage func(x, y, z *[batchSize]float64, n int)
+
+// perColor makes a batchStage out of a function converting a single color.
+func perColor(conv func(x, y, z float64) (float64, float64, float64)) batchStage {
+	return func(x, y, z *[batchSize]float64, n int) {
+		for i := 0; i < n; i++ {
+			x[i], y[i], z[i] = conv(x[i], y[i], z[i])
+		}
+	}
+}
+
+// Stages shared by several conversions.
+var (
+	linearRgbToXyzStage = perColor(LinearRgbToXyz)
+
+	// XYZ to Luv with the white reference of HSLuv, see Color.HSLuv.
+	hsluvLuvStage = perColor(func(x, y, z float64) (float64, float64, float64) {
+		return XyzToLuvWhiteRef(x, y, z, hSLuvD65)
+	})
+
+	// XYZ to absolute XYZ, placing sRGB white at SdrWhiteLuminance.
+	sdrWhiteStage = perColor(func(x, y, z float64) (float64, float64, float64) {
+		return x * SdrWhiteLuminance, y * SdrWhiteLuminance, z * SdrWhiteLuminance
+	})
+)
+
+// convertSlice linearizes the colors of src block by block, and then runs the
+// given stages over each block, each one over the whole block before the next.
+func convertSlice(dst [][3]float64, src []Color, stages ...batchStage) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+
+	var x, y, z [batchSize]float64
+	for lo := 0; lo < n; lo += batchSize {
+		block := src[lo:n]
+		if len(block) > batchSize {
+			block = block[:batchSize]
+		}
+		for i, col := range block {
+			x[i] = linearize8(col.R)
+		}
+		for i, col := range block {
+			y[i] = linearize8(col.G)
+		}
+		for i, col := range block {
+			z[i] = linearize8(col.B)
+		}
+		for _, stage := range stages {
+			stage(&x, &y, &z, len(block))
+		}
+		out := dst[lo : lo+len(block)]
+		for i := range out {
+			out[i] = [3]float64{x[i], y[i], z[i]}
+		}
+	}
+	return n
+}
+
+// convertSliceBack converts the values of src to dst with the given function.
+func convertSliceBack(dst []Color, src [][3]float64, conv func(x, y, z float64) Color) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i, v := range src[:n] {
+		dst[i] = conv(v[0], v[1], v[2])
+	}
+	return n
+}
+
+// convertSliceSrgb converts the colors of src to dst with the given method,
+// for the spaces which are computed from sRGB directly, and so have nothing
+// to gain from the linearization in convertSlice.
+func convertSliceSrgb(dst [][3]float64, src []Color, conv func(col Color) (float64, float64, float64)) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i, col := range src[:n] {
+		dst[i][0], dst[i][1], dst[i][2] = conv(col)
+	}
+	return n
+}
+
+// LinearRgbSlice converts the colors of src to linear RGB, like LinearRgb.
+func LinearRgbSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src)
+}
+
+// FromLinearRgbSlice converts linear RGB values to colors, like LinearRgb.
+func FromLinearRgbSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, LinearRgb)
+}
+
+// XyzSlice converts the colors of src to CIE XYZ, like Xyz.
+func XyzSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage)
+}
+
+// FromXyzSlice converts CIE XYZ values to colors, like Xyz.
+func FromXyzSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Xyz)
+}
+
+// LabSlice converts the colors of src to CIE L*a*b*, like Lab.
+func LabSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(XyzToLab))
+}
+
+// FromLabSlice converts CIE L*a*b* values to colors, like Lab.
+func FromLabSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Lab)
+}
+
+// LuvSlice converts the colors of src to CIE L*u*v*, like Luv.
+func LuvSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(XyzToLuv))
+}
+
+// FromLuvSlice converts CIE L*u*v* values to colors, like Luv.
+func FromLuvSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Luv)
+}
+
+// HclSlice converts the colors of src to HCL, in the order h, c, l, like Hcl.
+func HclSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(XyzToLab), perColor(LabToHcl))
+}
+
+// FromHclSlice converts HCL values, in the order h, c, l, to colors, like Hcl.
+func FromHclSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Hcl)
+}
+
+// OkLabSlice converts the colors of src to OkLab, like OkLab.
+func OkLabSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(XyzToOkLab))
+}
+
+// FromOkLabSlice converts OkLab values to colors, like OkLab.
+func FromOkLabSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, OkLab)
+}
+
+// OkLchSlice converts the colors of src to OkLch, like OkLch.
+func OkLchSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(XyzToOkLab), perColor(OkLabToOkLch))
+}
+
+// FromOkLchSlice converts OkLch values to colors, like OkLch.
+func FromOkLchSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, OkLch)
+}
+
+// HsvSlice converts the colors of src to HSV, like Hsv.
+func HsvSlice(dst [][3]float64, src []Color) int {
+	return convertSliceSrgb(dst, src, Color.Hsv)
+}
+
+// FromHsvSlice converts HSV values to colors, like Hsv.
+func FromHsvSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Hsv)
+}
+
+// HslSlice converts the colors of src to HSL, like Hsl.
+func HslSlice(dst [][3]float64, src []Color) int {
+	return convertSliceSrgb(dst, src, Color.Hsl)
+}
+
+// FromHslSlice converts HSL values to colors, like Hsl.
+func FromHslSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Hsl)
+}
+
+// XyySlice converts the colors of src to CIE xyY, like Xyy.
+func XyySlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(XyzToXyy))
+}
+
+// FromXyySlice converts CIE xyY values to colors, like Xyy.
+func FromXyySlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Xyy)
+}
+
+// LuvLChSlice converts the colors of src to LuvLCh, in the order l, c, h, like LuvLCh.
+func LuvLChSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(XyzToLuv), perColor(LuvToLuvLCh))
+}
+
+// FromLuvLChSlice converts LuvLCh values, in the order l, c, h, to colors, like LuvLCh.
+func FromLuvLChSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, LuvLCh)
+}
+
+// HSLuvSlice converts the colors of src to HSLuv, like HSLuv.
+func HSLuvSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, hsluvLuvStage, perColor(LuvToLuvLCh), perColor(LuvLChToHSLuv))
+}
+
+// FromHSLuvSlice converts HSLuv values to colors, like HSLuv.
+func FromHSLuvSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, HSLuv)
+}
+
+// HPLuvSlice converts the colors of src to HPLuv, like HPLuv.
+func HPLuvSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, hsluvLuvStage, perColor(LuvToLuvLCh), perColor(LuvLChToHPLuv))
+}
+
+// FromHPLuvSlice converts HPLuv values to colors, like HPLuv.
+func FromHPLuvSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, HPLuv)
+}
+
+// OkhslSlice converts the colors of src to Okhsl, like Okhsl.
+func OkhslSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, perColor(linearRgbToOkhsl))
+}
+
+// FromOkhslSlice converts Okhsl values to colors, like Okhsl.
+func FromOkhslSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Okhsl)
+}
+
+// OkhsvSlice converts the colors of src to Okhsv, like Okhsv.
+func OkhsvSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, perColor(linearRgbToOkhsv))
+}
+
+// FromOkhsvSlice converts Okhsv values to colors, like Okhsv.
+func FromOkhsvSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Okhsv)
+}
+
+// JzazbzSlice converts the colors of src to Jzazbz, with sRGB white at
+// SdrWhiteLuminance, like Jzazbz.
+func JzazbzSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, sdrWhiteStage, perColor(AbsoluteXyzToJzazbz))
+}
+
+// FromJzazbzSlice converts Jzazbz values to colors, like Jzazbz.
+func FromJzazbzSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Jzazbz)
+}
+
+// JzCzhzSlice converts the colors of src to JzCzhz, like JzCzhz.
+func JzCzhzSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, sdrWhiteStage, perColor(AbsoluteXyzToJzazbz), perColor(JzazbzToJzCzhz))
+}
+
+// FromJzCzhzSlice converts JzCzhz values to colors, like JzCzhz.
+func FromJzCzhzSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, JzCzhz)
+}
+
+// ICtCpSlice converts the colors of src to PQ-encoded ICtCp, with sRGB white
+// at SdrWhiteLuminance, like ICtCp.
+func ICtCpSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, sdrWhiteStage, perColor(AbsoluteXyzToICtCp))
+}
+
+// FromICtCpSlice converts PQ-encoded ICtCp values to colors, like ICtCp.
+func FromICtCpSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, ICtCp)
+}
+
+// Cam16UcsSlice converts the colors of src to CAM16-UCS under
+// DefaultViewingConditions, like Cam16Ucs.
+func Cam16UcsSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(func(x, y, z float64) (float64, float64, float64) {
+		return XyzToCam16Ucs(x, y, z, DefaultViewingConditions)
+	}))
+}
+
+// FromCam16UcsSlice converts CAM16-UCS values under DefaultViewingConditions
+// to colors, like Cam16Ucs.
+func FromCam16UcsSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Cam16Ucs)
+}
+
+// Cam16JchSlice converts the colors of src to CAM16 J, C and h under
+// DefaultViewingConditions, like Cam16Jch.
+func Cam16JchSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(func(x, y, z float64) (float64, float64, float64) {
+		cam := XyzToCam16(x, y, z, DefaultViewingConditions)
+		return cam.J, cam.C, cam.H
+	}))
+}
+
+// FromCam16JchSlice converts CAM16 J, C and h values under
+// DefaultViewingConditions to colors, like Cam16Jch.
+func FromCam16JchSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Cam16Jch)
+}
+
+// HctSlice converts the colors of src to HCT, like Hct.
+func HctSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, perColor(hctLinearRgbToXyz), perColor(xyzToHct))
+}
+
+// FromHctSlice converts HCT values to colors, like Hct.
+func FromHctSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Hct)
+}
+
+// Din99oSlice converts the colors of src to DIN99o, like Din99o.
+func Din99oSlice(dst [][3]float64, src []Color) int {
+	return convertSlice(dst, src, linearRgbToXyzStage, perColor(XyzToLab), perColor(LabToDin99o))
+}
+
+// FromDin99oSlice converts DIN99o values to colors, like Din99o.
+func FromDin99oSlice(dst []Color, src [][3]float64) int {
+	return convertSliceBack(dst, src, Din99o)
+}
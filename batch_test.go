@@ -0,0 +1,129 @@
+package colorful
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func batchColors() []Color {
+	cols := make([]Color, 0, len(vals)+300)
+	for _, tt := range vals {
+		cols = append(cols, tt.c)
+	}
+	// Enough to span several blocks, with both 8-bit and arbitrary colors.
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 150; i++ {
+		cols = append(cols, Color{rng.Float64(), rng.Float64(), rng.Float64()})
+		r, g, b := uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256))
+		cols = append(cols, Color{float64(r) / 255.0, float64(g) / 255.0, float64(b) / 255.0})
+	}
+	return cols
+}
+
+func TestBatchSlices(t *testing.T) {
+	tests := []struct {
+		name string
+		to   func([][3]float64, []Color) int
+		from func([]Color, [][3]float64) int
+		conv func(Color) (float64, float64, float64)
+	}{
+		{"LinearRgb", LinearRgbSlice, FromLinearRgbSlice, Color.LinearRgb},
+		{"Xyz", XyzSlice, FromXyzSlice, Color.Xyz},
+		{"Lab", LabSlice, FromLabSlice, Color.Lab},
+		{"Luv", LuvSlice, FromLuvSlice, Color.Luv},
+		{"Hcl", HclSlice, FromHclSlice, Color.Hcl},
+		{"OkLab", OkLabSlice, FromOkLabSlice, Color.OkLab},
+		{"OkLch", OkLchSlice, FromOkLchSlice, Color.OkLch},
+		{"Hsv", HsvSlice, FromHsvSlice, Color.Hsv},
+		{"Hsl", HslSlice, FromHslSlice, Color.Hsl},
+		{"Xyy", XyySlice, FromXyySlice, Color.Xyy},
+		{"LuvLCh", LuvLChSlice, FromLuvLChSlice, Color.LuvLCh},
+		{"HSLuv", HSLuvSlice, FromHSLuvSlice, Color.HSLuv},
+		{"HPLuv", HPLuvSlice, FromHPLuvSlice, Color.HPLuv},
+		{"Okhsl", OkhslSlice, FromOkhslSlice, Color.Okhsl},
+		{"Okhsv", OkhsvSlice, FromOkhsvSlice, Color.Okhsv},
+		{"Jzazbz", JzazbzSlice, FromJzazbzSlice, Color.Jzazbz},
+		{"JzCzhz", JzCzhzSlice, FromJzCzhzSlice, Color.JzCzhz},
+		{"ICtCp", ICtCpSlice, FromICtCpSlice, Color.ICtCp},
+		{"Cam16Ucs", Cam16UcsSlice, FromCam16UcsSlice, Color.Cam16Ucs},
+		{"Cam16Jch", Cam16JchSlice, FromCam16JchSlice, Color.Cam16Jch},
+		{"Hct", HctSlice, FromHctSlice, Color.Hct},
+		{"Din99o", Din99oSlice, FromDin99oSlice, Color.Din99o},
+	}
+
+	cols := batchColors()
+	for _, tt := range tests {
+		vs := make([][3]float64, len(cols))
+		if n := tt.to(vs, cols); n != len(cols) {
+			t.Errorf("%vSlice converted %v colors, want %v", tt.name, n, len(cols))
+		}
+		for i, col := range cols {
+			x, y, z := tt.conv(col)
+			if math.Abs(vs[i][0]-x) > 1e-12 || math.Abs(vs[i][1]-y) > 1e-12 || math.Abs(vs[i][2]-z) > 1e-12 {
+				t.Errorf("%v. %vSlice of %v => %v, want [%v %v %v]", i, tt.name, col, vs[i], x, y, z)
+			}
+		}
+
+		back := make([]Color, len(vs))
+		if n := tt.from(back, vs); n != len(vs) {
+			t.Errorf("From%vSlice converted %v colors, want %v", tt.name, n, len(vs))
+		}
+		for i, col := range cols {
+			if !back[i].AlmostEqualRgb(col) {
+				t.Errorf("%v. From%vSlice(%vSlice(%v)) => %v", i, tt.name, tt.name, col, back[i])
+			}
+		}
+	}
+}
+
+func TestBatchSliceLengths(t *testing.T) {
+	cols := batchColors()
+	vs := make([][3]float64, 70)
+	if n := LabSlice(vs, cols); n != 70 {
+		t.Errorf("LabSlice into shorter dst converted %v colors, want 70", n)
+	}
+	if n := LabSlice(vs, cols[:3]); n != 3 {
+		t.Errorf("LabSlice from shorter src converted %v colors, want 3", n)
+	}
+	if n := FromLabSlice(make([]Color, 5), vs); n != 5 {
+		t.Errorf("FromLabSlice into shorter dst converted %v colors, want 5", n)
+	}
+	if n := LabSlice(nil, cols); n != 0 {
+		t.Errorf("LabSlice into nil converted %v colors, want 0", n)
+	}
+}
+
+func TestLinearize8(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		v := float64(i) / 255.0
+		if l := linearize8(v); l != linearize(v) {
+			t.Errorf("linearize8(%v) => %v, want %v", v, l, linearize(v))
+		}
+	}
+	for _, v := range []float64{-0.5, 0.5, 1.5, 2.0 / 255.0 * 1.0001, math.NaN()} {
+		if l, want := linearize8(v), linearize(v); l != want && !(math.IsNaN(l) && math.IsNaN(want)) {
+			t.Errorf("linearize8(%v) => %v, want %v", v, l, want)
+		}
+	}
+}
+
+func BenchmarkLabSlice(bench *testing.B) {
+	cols := batchColors()
+	vs := make([][3]float64, len(cols))
+	bench.ResetTimer()
+	for n := 0; n < bench.N; n++ {
+		LabSlice(vs, cols)
+	}
+}
+
+func BenchmarkLabLoop(bench *testing.B) {
+	cols := batchColors()
+	vs := make([][3]float64, len(cols))
+	bench.ResetTimer()
+	for n := 0; n < bench.N; n++ {
+		for i, col := range cols {
+			vs[i][0], vs[i][1], vs[i][2] = col.Lab()
+		}
+	}
+}
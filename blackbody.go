@@ -0,0 +1,69 @@
+package colorful
+
+import "math"
+
+// BlackbodySpectrum returns the relative spectral power distribution of a
+// blackbody radiator of the given temperature in Kelvin, by Planck's law,
+// normalized to 100 at 560 nm.
+func BlackbodySpectrum(kelvin float64) Spectrum {
+	// The second radiation constant in nm·K.
+	const c2 = 1.4388e7
+
+	values := make([]float64, len(cie1931Cmf))
+	for i := range values {
+		wl := 380.0 + 5.0*float64(i)
+		values[i] = 100.0 * math.Pow(560.0/wl, 5.0) * math.Expm1(c2/(560.0*kelvin)) / math.Expm1(c2/(wl*kelvin))
+	}
+	return NewSpectrum(380, 5, values)
+}
+
+// DaylightSpectrum returns the relative spectral power distribution of CIE
+// daylight of the given correlated color temperature in Kelvin, normalized
+// to 100 at 560 nm. It is defined from 4000K to 25000K. D65 is DaylightSpectrum(6504).
+func DaylightSpectrum(kelvin float64) Spectrum {
+	x, y := CctToXyDaylight(kelvin)
+	m := 0.0241 + 0.2562*x - 0.7341*y
+	m1 := (-1.3515 - 1.7703*x + 5.9114*y) / m
+	m2 := (0.0300 - 31.4424*x + 30.0717*y) / m
+
+	values := make([]float64, len(daylightBasis))
+	for i, s := range daylightBasis {
+		values[i] = s[0] + m1*s[1] + m2*s[2]
+	}
+	return NewSpectrum(380, 10, values)
+}
+
+// lightColor turns the spectrum of a light source into a color, scaled such
+// that its largest linear RGB component is 1.
+func lightColor(s Spectrum) Color {
+	wref := s.WhiteRef(CIE1931)
+	r, g, b := XyzToLinearRgb(wref[0], wref[1], wref[2])
+	m := math.Max(r, math.Max(g, b))
+	return LinearRgb(r/m, g/m, b/m)
+}
+
+// Blackbody returns the color of a blackbody radiator of the given
+// temperature in Kelvin, i.e. the color of an incandescent light, as bright
+// as possible. Low temperatures are outside of the sRGB gamut and get clamped.
+func Blackbody(kelvin float64) Color {
+	return BlackbodyUnclamped(kelvin).Clamped()
+}
+
+// BlackbodyUnclamped is like Blackbody, but returns the color as is, even if
+// it is outside of the sRGB gamut, i.e. has negative components.
+func BlackbodyUnclamped(kelvin float64) Color {
+	return lightColor(BlackbodySpectrum(kelvin))
+}
+
+// Daylight returns the color of CIE daylight of the given correlated color
+// temperature in Kelvin, as bright as possible. It is defined from 4000K to
+// 25000K, and Daylight(6504) is white.
+func Daylight(kelvin float64) Color {
+	return DaylightUnclamped(kelvin).Clamped()
+}
+
+// DaylightUnclamped is like Daylight, but returns the color as is, even if it
+// is outside of the sRGB gamut.
+func DaylightUnclamped(kelvin float64) Color {
+	return lightColor(DaylightSpectrum(kelvin))
+}
@@ -0,0 +1,79 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlackbodySpectrum(t *testing.T) {
+	// Illuminant A is a blackbody at 2856K, on the current temperature scale.
+	want, _ := StandardIlluminants["A"].WhiteRef(CIE1931)
+	if wref := BlackbodySpectrum(2856).WhiteRef(CIE1931); math.Abs(wref[0]-want[0]) > 1e-3 || math.Abs(wref[2]-want[2]) > 1e-3 {
+		t.Errorf("BlackbodySpectrum(2856).WhiteRef() => %v, want %v", wref, want)
+	}
+	if v := BlackbodySpectrum(5000).At(560); math.Abs(v-100) > 1e-9 {
+		t.Errorf("BlackbodySpectrum(5000).At(560) => %v, want 100", v)
+	}
+
+	// Its chromaticity lies on the Planckian locus.
+	for _, cct := range []float64{2000, 3000, 5000, 8000} {
+		wref := BlackbodySpectrum(cct).WhiteRef(CIE1931)
+		x, y, _ := XyzToXyy(wref[0], wref[1], wref[2])
+		if c := XyToCctRobertson(x, y); math.Abs(c-cct)/cct > 0.005 {
+			t.Errorf("CCT of BlackbodySpectrum(%v) => %v", cct, c)
+		}
+	}
+}
+
+func TestDaylightSpectrum(t *testing.T) {
+	d65 := DaylightSpectrum(6504)
+	for wl := 380.0; wl <= 780.0; wl += 10.0 {
+		if v, want := d65.At(wl), SpectrumD65.At(wl); math.Abs(v-want) > 0.5 {
+			t.Errorf("DaylightSpectrum(6504).At(%v) => %v, want %v", wl, v, want)
+		}
+	}
+
+	want, _ := StandardIlluminants["D50"].WhiteRef(CIE1931)
+	if wref := DaylightSpectrum(5003).WhiteRef(CIE1931); math.Abs(wref[0]-want[0]) > 1e-3 || math.Abs(wref[2]-want[2]) > 1e-3 {
+		t.Errorf("DaylightSpectrum(5003).WhiteRef() => %v, want %v", wref, want)
+	}
+}
+
+func TestBlackbody(t *testing.T) {
+	tests := []struct {
+		kelvin float64
+		hex    string
+	}{
+		{1900, "#ff8400"},
+		{2856, "#ffb264"},
+		{5000, "#ffe6d0"},
+		{10000, "#cdd9ff"},
+	}
+	for i, tt := range tests {
+		if c := Blackbody(tt.kelvin); c.Hex() != tt.hex {
+			t.Errorf("%v. Blackbody(%v) => %v, want %v", i, tt.kelvin, c.Hex(), tt.hex)
+		}
+	}
+
+	// Very low temperatures are out of the sRGB gamut.
+	if c := BlackbodyUnclamped(1000); c.IsValid() || c.B >= 0 {
+		t.Errorf("BlackbodyUnclamped(1000) => %v, want negative blue", c)
+	}
+	if c := Blackbody(1000); !c.IsValid() {
+		t.Errorf("Blackbody(1000) => %v, want clamped", c)
+	}
+}
+
+func TestDaylight(t *testing.T) {
+	if c := Daylight(6504); !c.AlmostEqualRgb(Color{1, 1, 1}) {
+		t.Errorf("Daylight(6504) => %v, want white", c)
+	}
+
+	// Warmer is redder, colder is bluer.
+	if c := Daylight(4000); c.R < 1-1e-9 || c.B > 0.7 {
+		t.Errorf("Daylight(4000) => %v, want warm", c)
+	}
+	if c := DaylightUnclamped(20000); c.B < 1-1e-9 || c.R > 0.7 {
+		t.Errorf("DaylightUnclamped(20000) => %v, want cold", c)
+	}
+}
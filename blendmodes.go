@@ -0,0 +1,181 @@
+package colorful
+
+import "math"
+
+// Blend modes combine a source color drawn onto a backdrop color, like the
+// layer modes of image editors. Unlike the Blend methods, they don't
+// interpolate, and they work on the sRGB values, like CSS and most editors.
+//
+// Source: https://www.w3.org/TR/compositing-1/#blending
+
+// A BlendMode is one of the W3C Compositing Level 1 blend modes.
+type BlendMode int
+
+const (
+	// The source, as if there was no blending.
+	ModeNormal BlendMode = iota
+	// Multiplies the channels, darkening like two slides on top of each other.
+	ModeMultiply
+	// Multiplies the inverse channels, lightening like two projectors.
+	ModeScreen
+	// Multiply or screen, depending on the backdrop.
+	ModeOverlay
+	// The darker of the two, per channel.
+	ModeDarken
+	// The lighter of the two, per channel.
+	ModeLighten
+	// Brightens the backdrop to reflect the source.
+	ModeColorDodge
+	// Darkens the backdrop to reflect the source.
+	ModeColorBurn
+	// Multiply or screen, depending on the source.
+	ModeHardLight
+	// Darkens or lightens, depending on the source, like a diffused spotlight.
+	ModeSoftLight
+	// The absolute difference, per channel.
+	ModeDifference
+	// Like ModeDifference, but with lower contrast.
+	ModeExclusion
+	// The hue of the source with the saturation and luminosity of the backdrop.
+	ModeHue
+	// The saturation of the source with the hue and luminosity of the backdrop.
+	ModeSaturation
+	// The hue and saturation of the source with the luminosity of the backdrop.
+	ModeColor
+	// The luminosity of the source with the hue and saturation of the backdrop.
+	ModeLuminosity
+)
+
+// Blended returns the color resulting from blending src onto cb, the
+// backdrop, with the given mode. Both colors should be valid, as should be
+// the result.
+func (cb Color) Blended(src Color, mode BlendMode) Color {
+	switch mode {
+	case ModeHue:
+		return setLum(setSat(src, sat(cb)), lum(cb))
+	case ModeSaturation:
+		return setLum(setSat(cb, sat(src)), lum(cb))
+	case ModeColor:
+		return setLum(src, lum(cb))
+	case ModeLuminosity:
+		return setLum(cb, lum(src))
+	}
+	return Color{
+		mode.blendChannel(cb.R, src.R),
+		mode.blendChannel(cb.G, src.G),
+		mode.blendChannel(cb.B, src.B),
+	}
+}
+
+// blendChannel blends a channel of the separable modes.
+func (mode BlendMode) blendChannel(cb, cs float64) float64 {
+	switch mode {
+	case ModeMultiply:
+		return cb * cs
+	case ModeScreen:
+		return cb + cs - cb*cs
+	case ModeOverlay:
+		return ModeHardLight.blendChannel(cs, cb)
+	case ModeDarken:
+		return math.Min(cb, cs)
+	case ModeLighten:
+		return math.Max(cb, cs)
+	case ModeColorDodge:
+		if cb == 0 {
+			return 0
+		} else if cs >= 1 {
+			return 1
+		}
+		return math.Min(1, cb/(1-cs))
+	case ModeColorBurn:
+		if cb >= 1 {
+			return 1
+		} else if cs <= 0 {
+			return 0
+		}
+		return 1 - math.Min(1, (1-cb)/cs)
+	case ModeHardLight:
+		if cs <= 0.5 {
+			return ModeMultiply.blendChannel(cb, 2*cs)
+		}
+		return ModeScreen.blendChannel(cb, 2*cs-1)
+	case ModeSoftLight:
+		if cs <= 0.5 {
+			return cb - (1-2*cs)*cb*(1-cb)
+		}
+		d := math.Sqrt(cb)
+		if cb <= 0.25 {
+			d = ((16*cb-12)*cb + 4) * cb
+		}
+		return cb + (2*cs-1)*(d-cb)
+	case ModeDifference:
+		return math.Abs(cb - cs)
+	case ModeExclusion:
+		return cb + cs - 2*cb*cs
+	}
+	return cs
+}
+
+// The helpers of the non-separable modes, which work on a simple luminosity
+// in sRGB, not on any of the color spaces of this library.
+
+func lum(c Color) float64 {
+	return 0.3*c.R + 0.59*c.G + 0.11*c.B
+}
+
+func clipColor(c Color) Color {
+	l := lum(c)
+	n := math.Min(c.R, math.Min(c.G, c.B))
+	x := math.Max(c.R, math.Max(c.G, c.B))
+	if n < 0 {
+		c = Color{l + (c.R-l)*l/(l-n), l + (c.G-l)*l/(l-n), l + (c.B-l)*l/(l-n)}
+	}
+	if x > 1 {
+		c = Color{l + (c.R-l)*(1-l)/(x-l), l + (c.G-l)*(1-l)/(x-l), l + (c.B-l)*(1-l)/(x-l)}
+	}
+	return c
+}
+
+func setLum(c Color, l float64) Color {
+	d := l - lum(c)
+	return clipColor(Color{c.R + d, c.G + d, c.B + d})
+}
+
+func sat(c Color) float64 {
+	return math.Max(c.R, math.Max(c.G, c.B)) - math.Min(c.R, math.Min(c.G, c.B))
+}
+
+func setSat(c Color, s float64) Color {
+	ch := [3]float64{c.R, c.G, c.B}
+
+	// Find the indices of the smallest, middle and largest channel.
+	min, mid, max := 0, 1, 2
+	if ch[min] > ch[mid] {
+		min, mid = mid, min
+	}
+	if ch[mid] > ch[max] {
+		mid, max = max, mid
+	}
+	if ch[min] > ch[mid] {
+		min, mid = mid, min
+	}
+
+	if ch[max] > ch[min] {
+		ch[mid] = (ch[mid] - ch[min]) * s / (ch[max] - ch[min])
+		ch[max] = s
+	} else {
+		ch[mid], ch[max] = 0, 0
+	}
+	ch[min] = 0
+	return Color{ch[0], ch[1], ch[2]}
+}
+
+// CompositeBlended blends src onto dst with the given mode and puts the
+// result over dst, like CSS mix-blend-mode does. Where dst is transparent,
+// src is drawn as is.
+func (src ColorA) CompositeBlended(dst ColorA, mode BlendMode) ColorA {
+	// The blended color only shows where the backdrop is.
+	blended := dst.Color.Blended(src.Color, mode)
+	mixed := ColorA{src.Color.BlendRgb(blended, dst.A), src.A}
+	return mixed.Composite(dst, OpSrcOver)
+}
@@ -0,0 +1,90 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlendedSeparable(t *testing.T) {
+	tests := []struct {
+		mode    BlendMode
+		cb, cs  Color
+		blended Color
+	}{
+		{ModeNormal, Color{0.5, 0.25, 1}, Color{0.5, 1, 0.5}, Color{0.5, 1, 0.5}},
+		{ModeMultiply, Color{0.5, 0.25, 1}, Color{0.5, 1, 0.5}, Color{0.25, 0.25, 0.5}},
+		{ModeScreen, Color{0.5, 0.25, 1}, Color{0.5, 1, 0.5}, Color{0.75, 1, 1}},
+		{ModeOverlay, Color{0.25, 0.75, 0.5}, Color{0.5, 0.5, 0.2}, Color{0.25, 0.75, 0.2}},
+		{ModeDarken, Color{0.5, 0.25, 1}, Color{0.5, 1, 0.5}, Color{0.5, 0.25, 0.5}},
+		{ModeLighten, Color{0.5, 0.25, 1}, Color{0.5, 1, 0.5}, Color{0.5, 1, 1}},
+		{ModeColorDodge, Color{0, 0.25, 0.5}, Color{0.5, 1, 0.75}, Color{0, 1, 1}},
+		{ModeColorBurn, Color{1, 0.25, 0.5}, Color{0.5, 0, 0.75}, Color{1, 0, 1.0 / 3.0}},
+		{ModeHardLight, Color{0.5, 0.5, 0.5}, Color{0.25, 0.5, 0.75}, Color{0.25, 0.5, 0.75}},
+		{ModeSoftLight, Color{0.25, 0.64, 0.5}, Color{1, 1, 0.5}, Color{0.5, 0.8, 0.5}},
+		{ModeSoftLight, Color{0.5, 0.5, 0.5}, Color{0, 0.25, 0.5}, Color{0.25, 0.375, 0.5}},
+		{ModeDifference, Color{0.5, 0.25, 1}, Color{0.5, 1, 0.5}, Color{0, 0.75, 0.5}},
+		{ModeExclusion, Color{0.5, 0.25, 1}, Color{0.5, 1, 0.5}, Color{0.5, 0.75, 0.5}},
+	}
+	for i, tt := range tests {
+		if c := tt.cb.Blended(tt.cs, tt.mode); math.Abs(c.R-tt.blended.R) > 1e-12 || math.Abs(c.G-tt.blended.G) > 1e-12 || math.Abs(c.B-tt.blended.B) > 1e-12 {
+			t.Errorf("%v. %v.Blended(%v, %v) => %v, want %v", i, tt.cb, tt.cs, tt.mode, c, tt.blended)
+		}
+	}
+
+	// Overlay is hard light with the colors swapped.
+	c1, c2 := Color{0.2, 0.6, 0.9}, Color{0.7, 0.1, 0.4}
+	if o, h := c1.Blended(c2, ModeOverlay), c2.Blended(c1, ModeHardLight); o != h {
+		t.Errorf("Overlay %v is not swapped hard light %v", o, h)
+	}
+}
+
+func TestBlendedNonSeparable(t *testing.T) {
+	red, gray := Color{1, 0, 0}, Color{0.5, 0.5, 0.5}
+
+	// Gray has no hue or saturation to give.
+	if c := gray.Blended(red, ModeHue); !c.AlmostEqualRgb(gray) {
+		t.Errorf("%v.Blended(%v, ModeHue) => %v, want %v", gray, red, c, gray)
+	}
+	if c := gray.Blended(red, ModeSaturation); !c.AlmostEqualRgb(gray) {
+		t.Errorf("%v.Blended(%v, ModeSaturation) => %v, want %v", gray, red, c, gray)
+	}
+
+	// Red is made brighter and clipped back into the gamut.
+	want := Color{1, 0.5 - 0.3*0.5/0.7, 0.5 - 0.3*0.5/0.7}
+	if c := gray.Blended(red, ModeColor); math.Abs(c.R-want.R) > 1e-12 || math.Abs(c.G-want.G) > 1e-12 || math.Abs(c.B-want.B) > 1e-12 {
+		t.Errorf("%v.Blended(%v, ModeColor) => %v, want %v", gray, red, c, want)
+	}
+	if c := red.Blended(gray, ModeLuminosity); math.Abs(c.R-want.R) > 1e-12 || math.Abs(c.G-want.G) > 1e-12 || math.Abs(c.B-want.B) > 1e-12 {
+		t.Errorf("%v.Blended(%v, ModeLuminosity) => %v, want %v", red, gray, c, want)
+	}
+
+	// Without clipping, the luminosity and saturation come from where they should.
+	cb, cs := Color{0.4, 0.5, 0.3}, Color{0.6, 0.3, 0.5}
+	if c := cb.Blended(cs, ModeHue); math.Abs(lum(c)-lum(cb)) > 1e-12 || math.Abs(sat(c)-sat(cb)) > 1e-12 {
+		t.Errorf("%v.Blended(%v, ModeHue) => %v, lum %v and sat %v, want %v and %v", cb, cs, c, lum(c), sat(c), lum(cb), sat(cb))
+	}
+	if c := cb.Blended(cs, ModeSaturation); math.Abs(lum(c)-lum(cb)) > 1e-12 || math.Abs(sat(c)-sat(cs)) > 1e-12 {
+		t.Errorf("%v.Blended(%v, ModeSaturation) => %v, lum %v and sat %v, want %v and %v", cb, cs, c, lum(c), sat(c), lum(cb), sat(cs))
+	}
+	if c := cb.Blended(cs, ModeLuminosity); math.Abs(lum(c)-lum(cs)) > 1e-12 || math.Abs(sat(c)-sat(cb)) > 1e-12 {
+		t.Errorf("%v.Blended(%v, ModeLuminosity) => %v, lum %v and sat %v, want %v and %v", cb, cs, c, lum(c), sat(c), lum(cs), sat(cb))
+	}
+}
+
+func TestCompositeBlended(t *testing.T) {
+	cb, cs := Color{0.5, 0.25, 1}, Color{0.5, 1, 0.5}
+
+	// Opaque on opaque is just the blended color.
+	if c := (ColorA{cs, 1}).CompositeBlended(ColorA{cb, 1}, ModeMultiply); !c.AlmostEqualRgba(ColorA{cb.Blended(cs, ModeMultiply), 1}) {
+		t.Errorf("Opaque CompositeBlended => %v, want %v", c, cb.Blended(cs, ModeMultiply))
+	}
+	// There is nothing to blend with on a transparent backdrop.
+	if c := (ColorA{cs, 0.5}).CompositeBlended(ColorA{}, ModeMultiply); !c.AlmostEqualRgba(ColorA{cs, 0.5}) {
+		t.Errorf("CompositeBlended on transparent => %v, want %v", c, ColorA{cs, 0.5})
+	}
+	// A half transparent source is halfway between the backdrop and the blended color.
+	want := cb.BlendRgb(cb.Blended(cs, ModeScreen), 0.5)
+	if c := (ColorA{cs, 0.5}).CompositeBlended(ColorA{cb, 1}, ModeScreen); !c.AlmostEqualRgba(ColorA{want, 1}) {
+		t.Errorf("Half transparent CompositeBlended => %v, want %v", c, want)
+	}
+}
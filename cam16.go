@@ -0,0 +1,347 @@
+package colorful
+
+import "math"
+
+// CAM16 is a color appearance model, which means that unlike L*a*b* it takes
+// the conditions under which a color is viewed into account: how bright the
+// adapting field is, how light the background is, the surround and the white
+// point the observer is adapted to.
+//
+// Sources:
+//
+//     Li et al., "Comprehensive color solutions: CAM16, CAT16, and CAM16-UCS",
+//     Color Research & Application 42(6), 2017.
+//     https://github.com/material-foundation/material-color-utilities
+//
+// Unlike most other color spaces in this library, all correlates are kept in
+// their conventional CAM16 ranges, i.e. J is in [0..100] and h in [0..360].
+
+// Surround describes the relative luminance of the area surrounding the
+// stimulus and the background, for example a dim room for a television or a
+// dark cinema.
+type Surround int
+
+const (
+	// SurroundAverage is for surface colors, e.g. print viewed in daylight.
+	SurroundAverage Surround = iota
+	// SurroundDim is for e.g. television viewed in a dim room.
+	SurroundDim
+	// SurroundDark is for e.g. projections in a dark room.
+	SurroundDark
+)
+
+// factors returns the CAM16 surround factors F, c and Nc.
+func (s Surround) factors() (f, c, nc float64) {
+	switch s {
+	case SurroundDim:
+		return 0.9, 0.59, 0.9
+	case SurroundDark:
+		return 0.8, 0.525, 0.8
+	default:
+		return 1.0, 0.69, 1.0
+	}
+}
+
+// ViewingConditions holds everything CAM16 needs to know about how a color is
+// viewed, along with the values derived from it. Create it using
+// NewViewingConditions, since the derived values are only computed there.
+type ViewingConditions struct {
+	n, aw, nbb, ncb, c, nc, fl, flRoot, z float64
+	rgbD                                  [3]float64
+}
+
+// NewViewingConditions computes viewing conditions for CAM16.
+//
+// wref is the adopted white in XYZ, using the same convention as D65 and D50.
+// adaptingLuminance is the luminance of the adapting field in cd/m², often
+// taken to be 20% of the luminance of white. backgroundY is the relative
+// luminance Y of the background in [0..1], where 0.2 is the usual medium gray.
+// If discountIlluminant is true, the observer is assumed to be fully adapted
+// to wref.
+func NewViewingConditions(wref [3]float64, adaptingLuminance, backgroundY float64, surround Surround, discountIlluminant bool) ViewingConditions {
+	var vc ViewingConditions
+
+	// CAM16 expects XYZ in [0..100] instead of [0..1].
+	xw, yw, zw := wref[0]*100.0, wref[1]*100.0, wref[2]*100.0
+	rw, gw, bw := cam16Cone(xw, yw, zw)
+
+	f, c, nc := surround.factors()
+	vc.c = c
+	vc.nc = nc
+
+	d := 1.0
+	if !discountIlluminant {
+		d = clamp01(f * (1.0 - (1.0/3.6)*math.Exp((-adaptingLuminance-42.0)/92.0)))
+	}
+	vc.rgbD = [3]float64{
+		d*(yw/rw) + 1.0 - d,
+		d*(yw/gw) + 1.0 - d,
+		d*(yw/bw) + 1.0 - d,
+	}
+
+	k := 1.0 / (5.0*adaptingLuminance + 1.0)
+	k4 := k * k * k * k
+	k4F := 1.0 - k4
+	vc.fl = k4*adaptingLuminance + 0.1*k4F*k4F*math.Cbrt(5.0*adaptingLuminance)
+	vc.flRoot = math.Pow(vc.fl, 0.25)
+
+	vc.n = backgroundY / wref[1]
+	vc.z = 1.48 + math.Sqrt(vc.n)
+	vc.nbb = 0.725 / math.Pow(vc.n, 0.2)
+	vc.ncb = vc.nbb
+
+	ra := cam16Compress(vc.rgbD[0]*rw, vc.fl)
+	ga := cam16Compress(vc.rgbD[1]*gw, vc.fl)
+	ba := cam16Compress(vc.rgbD[2]*bw, vc.fl)
+	vc.aw = (2.0*ra + ga + 0.05*ba) * vc.nbb
+
+	return vc
+}
+
+// DefaultViewingConditions are the sRGB reference viewing conditions: a D65
+// display at 80 cd/m² in a 64 lux average surround, on a 20% gray background.
+var DefaultViewingConditions = NewViewingConditions(D65, 64.0/math.Pi*0.2, 0.2, SurroundAverage, false)
+
+// The CAT16 cone response matrix and its inverse.
+func cam16Cone(x, y, z float64) (r, g, b float64) {
+	r = 0.401288*x + 0.650173*y - 0.051461*z
+	g = -0.250268*x + 1.204414*y + 0.045854*z
+	b = -0.002079*x + 0.048952*y + 0.953127*z
+	return
+}
+
+func cam16ConeInv(r, g, b float64) (x, y, z float64) {
+	x = 1.862067855087233*r - 1.011254630531684*g + 0.1491867754444517*b
+	y = 0.3875265432361371*r + 0.6214474419314753*g - 0.00897398516761252*b
+	z = -0.01584149884933386*r - 0.03412293802851556*g + 1.049964436877849*b
+	return
+}
+
+// Post-adaptation non-linear response compression. The usual "+0.1" offset
+// is left out, since it cancels out in every correlate.
+func cam16Compress(v, fl float64) float64 {
+	f := math.Pow(fl*math.Abs(v)/100.0, 0.42)
+	return math.Copysign(400.0*f/(f+27.13), v)
+}
+
+func cam16Decompress(v, fl float64) float64 {
+	a := math.Abs(v)
+	base := math.Max(0, 27.13*a/(400.0-a))
+	return math.Copysign(100.0/fl*math.Pow(base, 1.0/0.42), v)
+}
+
+// Cam16 holds the CAM16 appearance correlates of a color:
+// lightness J, chroma C, hue angle H in degrees, colorfulness M,
+// saturation S and brightness Q.
+type Cam16 struct {
+	J, C, H, M, S, Q float64
+}
+
+// XyzToCam16 computes the CAM16 appearance correlates of the given CIE XYZ
+// color, as seen under the given viewing conditions.
+func XyzToCam16(x, y, z float64, vc ViewingConditions) Cam16 {
+	r, g, b := cam16Cone(x*100.0, y*100.0, z*100.0)
+	ra := cam16Compress(vc.rgbD[0]*r, vc.fl)
+	ga := cam16Compress(vc.rgbD[1]*g, vc.fl)
+	ba := cam16Compress(vc.rgbD[2]*b, vc.fl)
+
+	// Redness-greenness and yellowness-blueness.
+	a := (11.0*ra - 12.0*ga + ba) / 11.0
+	bb := (ra + ga - 2.0*ba) / 9.0
+
+	u := (20.0*ra + 20.0*ga + 21.0*ba) / 20.0
+	p2 := (40.0*ra + 20.0*ga + ba) / 20.0
+
+	h := math.Mod(57.29577951308232087721*math.Atan2(bb, a)+360.0, 360.0) // Rad2Deg
+
+	A := p2 * vc.nbb
+	J := 100.0 * math.Pow(A/vc.aw, vc.c*vc.z)
+	Q := 4.0 / vc.c * math.Sqrt(J/100.0) * (vc.aw + 4.0) * vc.flRoot
+
+	hp := h
+	if hp < 20.14 {
+		hp += 360.0
+	}
+	eHue := 0.25 * (math.Cos(hp*math.Pi/180.0+2.0) + 3.8)
+	p1 := 50000.0 / 13.0 * eHue * vc.nc * vc.ncb
+	t := p1 * math.Sqrt(a*a+bb*bb) / (u + 0.305)
+	alpha := math.Pow(t, 0.9) * math.Pow(1.64-math.Pow(0.29, vc.n), 0.73)
+
+	C := alpha * math.Sqrt(J/100.0)
+	M := C * vc.flRoot
+	s := 50.0 * math.Sqrt(alpha*vc.c/(vc.aw+4.0))
+
+	return Cam16{J: J, C: C, H: h, M: M, S: s, Q: Q}
+}
+
+// Cam16ToXyz converts CAM16 correlates back to CIE XYZ. Only J, C and H are
+// used, so use one of the Cam16From functions if you have different ones.
+func Cam16ToXyz(cam Cam16, vc ViewingConditions) (x, y, z float64) {
+	alpha := 0.0
+	if cam.C != 0.0 && cam.J != 0.0 {
+		alpha = cam.C / math.Sqrt(cam.J/100.0)
+	}
+	t := math.Pow(alpha/math.Pow(1.64-math.Pow(0.29, vc.n), 0.73), 1.0/0.9)
+
+	hRad := cam.H * math.Pi / 180.0
+	eHue := 0.25 * (math.Cos(hRad+2.0) + 3.8)
+	ac := vc.aw * math.Pow(cam.J/100.0, 1.0/vc.c/vc.z)
+	p1 := eHue * (50000.0 / 13.0) * vc.nc * vc.ncb
+	p2 := ac / vc.nbb
+
+	hSin, hCos := math.Sincos(hRad)
+	gamma := 23.0 * (p2 + 0.305) * t / (23.0*p1 + 11.0*t*hCos + 108.0*t*hSin)
+	a := gamma * hCos
+	b := gamma * hSin
+
+	ra := (460.0*p2 + 451.0*a + 288.0*b) / 1403.0
+	ga := (460.0*p2 - 891.0*a - 261.0*b) / 1403.0
+	ba := (460.0*p2 - 220.0*a - 6300.0*b) / 1403.0
+
+	r := cam16Decompress(ra, vc.fl) / vc.rgbD[0]
+	g := cam16Decompress(ga, vc.fl) / vc.rgbD[1]
+	bl := cam16Decompress(ba, vc.fl) / vc.rgbD[2]
+
+	x, y, z = cam16ConeInv(r, g, bl)
+	return x / 100.0, y / 100.0, z / 100.0
+}
+
+// Cam16FromJch computes all CAM16 correlates from lightness J, chroma C and hue h.
+func Cam16FromJch(j, c, h float64, vc ViewingConditions) Cam16 {
+	Q := 4.0 / vc.c * math.Sqrt(j/100.0) * (vc.aw + 4.0) * vc.flRoot
+	M := c * vc.flRoot
+	alpha := 0.0
+	if j != 0.0 {
+		alpha = c / math.Sqrt(j/100.0)
+	}
+	s := 50.0 * math.Sqrt(alpha*vc.c/(vc.aw+4.0))
+	return Cam16{J: j, C: c, H: h, M: M, S: s, Q: Q}
+}
+
+// Cam16FromJmh computes all CAM16 correlates from lightness J, colorfulness M and hue h.
+func Cam16FromJmh(j, m, h float64, vc ViewingConditions) Cam16 {
+	return Cam16FromJch(j, m/vc.flRoot, h, vc)
+}
+
+// Cam16FromJsh computes all CAM16 correlates from lightness J, saturation s and hue h.
+func Cam16FromJsh(j, s, h float64, vc ViewingConditions) Cam16 {
+	alpha := sq(s/50.0) * (vc.aw + 4.0) / vc.c
+	return Cam16FromJch(j, alpha*math.Sqrt(j/100.0), h, vc)
+}
+
+// Cam16FromQmh computes all CAM16 correlates from brightness Q, colorfulness M and hue h.
+func Cam16FromQmh(q, m, h float64, vc ViewingConditions) Cam16 {
+	j := 100.0 * sq(q*vc.c/(4.0*(vc.aw+4.0)*vc.flRoot))
+	return Cam16FromJmh(j, m, h, vc)
+}
+
+// Cam16 computes the CAM16 appearance correlates of the color, as seen under
+// the given viewing conditions.
+func (col Color) Cam16(vc ViewingConditions) Cam16 {
+	x, y, z := col.Xyz()
+	return XyzToCam16(x, y, z, vc)
+}
+
+// Color converts CAM16 appearance correlates seen under the given viewing
+// conditions back to a Color.
+// WARNING: many combinations of correlates do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func (cam Cam16) Color(vc ViewingConditions) Color {
+	return Xyz(Cam16ToXyz(cam, vc))
+}
+
+// Cam16Jch returns the CAM16 lightness J [0..100], chroma C and hue h [0..360]
+// of the color under DefaultViewingConditions.
+func (col Color) Cam16Jch() (j, c, h float64) {
+	cam := col.Cam16(DefaultViewingConditions)
+	return cam.J, cam.C, cam.H
+}
+
+// Cam16Jch generates a color from CAM16 lightness J [0..100], chroma C and
+// hue h [0..360] under DefaultViewingConditions.
+// WARNING: many combinations of `j`, `c`, and `h` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func Cam16Jch(j, c, h float64) Color {
+	return Cam16{J: j, C: c, H: h}.Color(DefaultViewingConditions)
+}
+
+/// CAM16-UCS ///
+/////////////////
+// CAM16-UCS is a uniform color space built on top of CAM16, in which Euclidean
+// distances match perceived color differences well. Just like L*a*b*, J', a'
+// and b' are scaled down by 100 so that J' is in [0..1] and DistanceCam16Ucs
+// is on the same scale as DistanceLab.
+
+// Cam16ToUcs converts CAM16 correlates to CAM16-UCS coordinates.
+func Cam16ToUcs(cam Cam16) (j, a, b float64) {
+	j = 1.7 * cam.J / (1.0 + 0.007*cam.J)
+	m := math.Log1p(0.0228*cam.M) / 0.0228
+	hSin, hCos := math.Sincos(cam.H * math.Pi / 180.0)
+	return j / 100.0, m * hCos / 100.0, m * hSin / 100.0
+}
+
+// UcsToCam16 converts CAM16-UCS coordinates back to CAM16 correlates.
+func UcsToCam16(j, a, b float64, vc ViewingConditions) Cam16 {
+	j, a, b = j*100.0, a*100.0, b*100.0
+	m := math.Expm1(math.Sqrt(a*a+b*b)*0.0228) / 0.0228
+	h := math.Mod(57.29577951308232087721*math.Atan2(b, a)+360.0, 360.0) // Rad2Deg
+	return Cam16FromJmh(j/(1.7-0.007*j), m, h, vc)
+}
+
+// XyzToCam16Ucs converts from CIE XYZ-space to CAM16-UCS, taking into account
+// the given viewing conditions.
+func XyzToCam16Ucs(x, y, z float64, vc ViewingConditions) (j, a, b float64) {
+	return Cam16ToUcs(XyzToCam16(x, y, z, vc))
+}
+
+// Cam16UcsToXyz converts from CAM16-UCS to CIE XYZ-space, taking into account
+// the given viewing conditions.
+func Cam16UcsToXyz(j, a, b float64, vc ViewingConditions) (x, y, z float64) {
+	return Cam16ToXyz(UcsToCam16(j, a, b, vc), vc)
+}
+
+// Cam16Ucs converts the given color to CAM16-UCS under DefaultViewingConditions.
+// J' is in [0..1] and both a' and b' are in about [-0.5..0.5]
+func (col Color) Cam16Ucs() (j, a, b float64) {
+	return col.Cam16UcsViewingConditions(DefaultViewingConditions)
+}
+
+// Cam16UcsViewingConditions converts the given color to CAM16-UCS, taking
+// into account the given viewing conditions.
+func (col Color) Cam16UcsViewingConditions(vc ViewingConditions) (j, a, b float64) {
+	x, y, z := col.Xyz()
+	return XyzToCam16Ucs(x, y, z, vc)
+}
+
+// Cam16Ucs generates a color by using data given in CAM16-UCS under
+// DefaultViewingConditions.
+// WARNING: many combinations of `j`, `a`, and `b` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func Cam16Ucs(j, a, b float64) Color {
+	return Cam16UcsViewingConditions(j, a, b, DefaultViewingConditions)
+}
+
+// Cam16UcsViewingConditions generates a color by using data given in
+// CAM16-UCS, taking into account the given viewing conditions.
+func Cam16UcsViewingConditions(j, a, b float64, vc ViewingConditions) Color {
+	return Xyz(Cam16UcsToXyz(j, a, b, vc))
+}
+
+// DistanceCam16Ucs is the Euclidean distance in CAM16-UCS. It is about as
+// accurate as DistanceCIEDE2000, but much cheaper and a proper metric, so it
+// can be used for spatial indices and clustering.
+func (c1 Color) DistanceCam16Ucs(c2 Color) float64 {
+	j1, a1, b1 := c1.Cam16Ucs()
+	j2, a2, b2 := c2.Cam16Ucs()
+	return math.Sqrt(sq(j1-j2) + sq(a1-a2) + sq(b1-b2))
+}
+
+// BlendCam16Ucs blends two colors in CAM16-UCS, which should result in a smoother blend.
+// t == 0 results in c1, t == 1 results in c2
+func (c1 Color) BlendCam16Ucs(c2 Color, t float64) Color {
+	j1, a1, b1 := c1.Cam16Ucs()
+	j2, a2, b2 := c2.Cam16Ucs()
+	return Cam16Ucs(j1+t*(j2-j1),
+		a1+t*(a2-a1),
+		b1+t*(b2-b1))
+}
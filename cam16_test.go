@@ -0,0 +1,99 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+// Ground-truth from the CAM16 example of https://colour.readthedocs.io
+func TestCam16Reference(t *testing.T) {
+	vc := NewViewingConditions([3]float64{0.9505, 1.0, 1.0888}, 318.31, 0.2, SurroundAverage, false)
+	want := Cam16{J: 41.731207905126638, C: 0.103355738709070, H: 217.067959767393010, M: 0.107436772335905, S: 2.345015041002290, Q: 195.371708971301910}
+
+	got := XyzToCam16(0.1901, 0.2000, 0.2178, vc)
+	if !almosteq_eps(got.J, want.J, 1e-6) || !almosteq_eps(got.C, want.C, 1e-6) || !almosteq_eps(got.H, want.H, 1e-6) ||
+		!almosteq_eps(got.M, want.M, 1e-6) || !almosteq_eps(got.S, want.S, 1e-6) || !almosteq_eps(got.Q, want.Q, 1e-6) {
+		t.Errorf("XyzToCam16(0.1901, 0.2000, 0.2178) => %+v, want %+v", got, want)
+	}
+
+	x, y, z := Cam16ToXyz(want, vc)
+	if !almosteq_eps(x, 0.1901, 1e-6) || !almosteq_eps(y, 0.2000, 1e-6) || !almosteq_eps(z, 0.2178, 1e-6) {
+		t.Errorf("Cam16ToXyz(%+v) => (%v), want [0.1901 0.2 0.2178]", want, []float64{x, y, z})
+	}
+}
+
+func TestCam16From(t *testing.T) {
+	for _, vc := range []ViewingConditions{
+		DefaultViewingConditions,
+		NewViewingConditions(D50, 200.0, 0.1, SurroundDim, false),
+		NewViewingConditions(D65, 10.0, 0.3, SurroundDark, true),
+	} {
+		for i, tt := range vals {
+			want := tt.c.Cam16(vc)
+			for _, got := range []Cam16{
+				Cam16FromJch(want.J, want.C, want.H, vc),
+				Cam16FromJmh(want.J, want.M, want.H, vc),
+				Cam16FromJsh(want.J, want.S, want.H, vc),
+				Cam16FromQmh(want.Q, want.M, want.H, vc),
+			} {
+				if math.Abs(got.J-want.J) > 1e-9 || math.Abs(got.C-want.C) > 1e-9 || math.Abs(got.M-want.M) > 1e-9 ||
+					math.Abs(got.S-want.S) > 1e-9 || math.Abs(got.Q-want.Q) > 1e-9 {
+					t.Errorf("%v. Cam16From* => %+v, want %+v", i, got, want)
+				}
+			}
+
+			if c := want.Color(vc); !c.AlmostEqualRgb(tt.c) {
+				t.Errorf("%v. %v.Cam16().Color() => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+			}
+		}
+	}
+}
+
+func TestCam16Jch(t *testing.T) {
+	for i, tt := range vals {
+		if c := Cam16Jch(tt.c.Cam16Jch()); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Cam16Jch(%v.Cam16Jch()) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+	}
+
+	// When fully adapted, the white has no chroma and full lightness.
+	vc := NewViewingConditions(D65, 64.0/math.Pi*0.2, 0.2, SurroundAverage, true)
+	cam := Color{1.0, 1.0, 1.0}.Cam16(vc)
+	if !almosteq_eps(cam.J, 100.0, 1e-5) || cam.C > 0.1 {
+		t.Errorf("white.Cam16() => %+v, want J 100, C 0", cam)
+	}
+}
+
+/// CAM16-UCS ///
+/////////////////
+
+func TestCam16UcsRoundtrip(t *testing.T) {
+	for i, tt := range vals {
+		if c := Cam16Ucs(tt.c.Cam16Ucs()); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Cam16Ucs(%v.Cam16Ucs()) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+	}
+}
+
+func TestCam16UcsReference(t *testing.T) {
+	// J'a'b' of the CAM16 example above is [54.90433134, -0.08442362, -0.06848314]
+	// in colour-science.
+	j, a, b := Cam16ToUcs(Cam16{J: 41.73109113, M: 0.10873867, H: 219.04843202})
+	if !almosteq_eps(j, 0.5490433134, 1e-8) || !almosteq_eps(a, -0.0008442362, 1e-8) || !almosteq_eps(b, -0.0006848314, 1e-8) {
+		t.Errorf("Cam16ToUcs(J: 41.73109113) => (%v, %v, %v), want (0.5490433134, -0.0008442362, -0.0006848314)", j, a, b)
+	}
+}
+
+func TestCam16UcsDistance(t *testing.T) {
+	for i, tt := range dists {
+		d12 := tt.c1.DistanceCam16Ucs(tt.c2)
+		d21 := tt.c2.DistanceCam16Ucs(tt.c1)
+		if !almosteq_eps(d12, d21, 1e-9) {
+			t.Errorf("%v. DistanceCam16Ucs is not symmetric: %v vs %v", i, d12, d21)
+		}
+		// It should roughly agree with the much more expensive CIEDE2000.
+		if tt.d00 > 0 && (d12 < 0.25*tt.d00 || d12 > 4*tt.d00) {
+			t.Errorf("%v. %v.DistanceCam16Ucs(%v) => %v, too far from CIEDE2000's %v", i, tt.c1, tt.c2, d12, tt.d00)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package colorful
+
+import "sync"
+
+// Chromatic adaptation transforms predict how a color seen under one white
+// point looks under another, for example when converting between the D65 of
+// sRGB and the D50 of ICC profiles. Simply swapping the white reference, as
+// the *WhiteRef functions do, does not adapt the color.
+//
+// All transforms here are of the von Kries type: XYZ is converted into a cone
+// response space, scaled by the ratio of the white points there, and
+// converted back. They only differ in the cone response matrix.
+//
+// See http://www.brucelindbloom.com/Eqn_ChromAdapt.html
+
+// AdaptationMethod selects the cone response matrix of a chromatic adaptation transform.
+type AdaptationMethod int
+
+const (
+	// Bradford is the transform used by ICC profiles, and a good default.
+	Bradford AdaptationMethod = iota
+	// VonKries uses the Hunt-Pointer-Estevez cone fundamentals.
+	VonKries
+	// Cat02 is the transform of the CIECAM02 color appearance model.
+	Cat02
+	// Cat16 is the transform of the CAM16 color appearance model.
+	Cat16
+	// XyzScaling scales XYZ directly, it is the least accurate.
+	XyzScaling
+)
+
+var adaptationCones = [...][3][3]float64{
+	Bradford: {
+		{0.8951, 0.2664, -0.1614},
+		{-0.7502, 1.7135, 0.0367},
+		{0.0389, -0.0685, 1.0296},
+	},
+	VonKries: {
+		{0.40024, 0.70760, -0.08081},
+		{-0.22630, 1.16532, 0.04570},
+		{0.0, 0.0, 0.91822},
+	},
+	Cat02: {
+		{0.7328, 0.4296, -0.1624},
+		{-0.7036, 1.6975, 0.0061},
+		{0.0030, 0.0136, 0.9834},
+	},
+	Cat16: {
+		{0.401288, 0.650173, -0.051461},
+		{-0.250268, 1.204414, 0.045854},
+		{-0.002079, 0.048952, 0.953127},
+	},
+	XyzScaling: {
+		{1.0, 0.0, 0.0},
+		{0.0, 1.0, 0.0},
+		{0.0, 0.0, 1.0},
+	},
+}
+
+type adaptationKey struct {
+	src, dst [3]float64
+	method   AdaptationMethod
+}
+
+// Adaptation matrices between the preset white points are cached, since
+// converting many colors between the same pair of them is by far the most
+// common use. Other white points, e.g. from WhiteRefFromKelvin, can take any
+// value, so caching them would grow without bound; their matrix is computed
+// every time instead, which is cheap anyway.
+var adaptationCache sync.Map
+
+// adaptationPresets are the white points whose matrices are cached: D65, D50
+// and the standard illuminants for both observers.
+var adaptationPresets = func() map[[3]float64]bool {
+	presets := map[[3]float64]bool{D65: true, D50: true}
+	for _, il := range StandardIlluminants {
+		for _, obs := range []Observer{CIE1931, CIE1964} {
+			if wref, ok := il.WhiteRef(obs); ok {
+				presets[wref] = true
+			}
+		}
+	}
+	return presets
+}()
+
+// AdaptationMatrix returns the matrix which adapts CIE XYZ under the src
+// white reference to CIE XYZ under the dst white reference, using the given
+// method. It panics if method is not one of the AdaptationMethod constants.
+func AdaptationMatrix(src, dst [3]float64, method AdaptationMethod) [3][3]float64 {
+	if !adaptationPresets[src] || !adaptationPresets[dst] {
+		return adaptationMatrix(src, dst, method)
+	}
+
+	key := adaptationKey{src, dst, method}
+	if m, ok := adaptationCache.Load(key); ok {
+		return m.([3][3]float64)
+	}
+	m := adaptationMatrix(src, dst, method)
+	adaptationCache.Store(key, m)
+	return m
+}
+
+func adaptationMatrix(src, dst [3]float64, method AdaptationMethod) [3][3]float64 {
+	cone := adaptationCones[method]
+	sr, sg, sb := mat3Apply(cone, src[0], src[1], src[2])
+	dr, dg, db := mat3Apply(cone, dst[0], dst[1], dst[2])
+	scale := [3][3]float64{{dr / sr, 0, 0}, {0, dg / sg, 0}, {0, 0, db / sb}}
+	return mat3Mul(mat3Inv(cone), mat3Mul(scale, cone))
+}
+
+// AdaptXyz adapts the CIE XYZ color x, y, z seen under the src white reference
+// to the color which looks the same under the dst white reference.
+// For example, AdaptXyz(x, y, z, D65, D50, Bradford) is what ICC-based
+// workflows use to go from sRGB's XYZ to the profile connection space.
+func AdaptXyz(x, y, z float64, src, dst [3]float64, method AdaptationMethod) (xa, ya, za float64) {
+	return mat3Apply(AdaptationMatrix(src, dst, method), x, y, z)
+}
@@ -0,0 +1,91 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+var adaptationMethods = []AdaptationMethod{Bradford, VonKries, Cat02, Cat16, XyzScaling}
+
+func compareMat3(m, want [3][3]float64, eps float64) bool {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(m[i][j]-want[i][j]) > eps {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Ground-truth from http://www.brucelindbloom.com/Eqn_ChromAdapt.html
+func TestAdaptationMatrix(t *testing.T) {
+	tests := []struct {
+		method   AdaptationMethod
+		src, dst [3]float64
+		want     [3][3]float64
+	}{
+		{Bradford, D65, D50, [3][3]float64{
+			{1.0478112, 0.0228866, -0.0501270},
+			{0.0295424, 0.9904844, -0.0170491},
+			{-0.0092345, 0.0150436, 0.7521316},
+		}},
+		{Bradford, D50, D65, [3][3]float64{
+			{0.9555766, -0.0230393, 0.0631636},
+			{-0.0282895, 1.0099416, 0.0210077},
+			{0.0122982, -0.0204830, 1.3299098},
+		}},
+		{XyzScaling, D65, D50, [3][3]float64{
+			{1.0144665, 0.0, 0.0},
+			{0.0, 1.0, 0.0},
+			{0.0, 0.0, 0.7578869},
+		}},
+	}
+	for i, tt := range tests {
+		if m := AdaptationMatrix(tt.src, tt.dst, tt.method); !compareMat3(m, tt.want, 1e-6) {
+			t.Errorf("%v. AdaptationMatrix(%v, %v, %v) => %v, want %v", i, tt.src, tt.dst, tt.method, m, tt.want)
+		}
+	}
+}
+
+func TestAdaptXyz(t *testing.T) {
+	identity := [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for _, method := range adaptationMethods {
+		// The source white has to end up exactly on the destination white.
+		if x, y, z := AdaptXyz(D65[0], D65[1], D65[2], D65, D50, method); math.Abs(x-D50[0]) > 1e-12 || math.Abs(y-D50[1]) > 1e-12 || math.Abs(z-D50[2]) > 1e-12 {
+			t.Errorf("AdaptXyz(D65, D65, D50, %v) => (%v, %v, %v), want %v", method, x, y, z, D50)
+		}
+
+		if m := AdaptationMatrix(D65, D65, method); !compareMat3(m, identity, 1e-12) {
+			t.Errorf("AdaptationMatrix(D65, D65, %v) => %v, want identity", method, m)
+		}
+
+		for i, tt := range vals {
+			x, y, z := tt.c.Xyz()
+			xa, ya, za := AdaptXyz(x, y, z, D65, D50, method)
+			if c := Xyz(AdaptXyz(xa, ya, za, D50, D65, method)); !c.AlmostEqualRgb(tt.c) {
+				t.Errorf("%v. AdaptXyz roundtrip of %v with method %v => %v", i, tt.c, method, c)
+			}
+		}
+	}
+}
+
+func TestAdaptationMatrixCache(t *testing.T) {
+	m1 := AdaptationMatrix(D65, D50, Cat16)
+	if _, ok := adaptationCache.Load(adaptationKey{D65, D50, Cat16}); !ok {
+		t.Errorf("AdaptationMatrix(D65, D50, Cat16) was not cached")
+	}
+	if m2 := AdaptationMatrix(D65, D50, Cat16); m1 != m2 {
+		t.Errorf("Cached AdaptationMatrix(D65, D50, Cat16) => %v, want %v", m2, m1)
+	}
+
+	// Arbitrary white points are not cached, or sweeping them would leak memory.
+	wref := [3]float64{0.97, 1.0, 0.91}
+	m3 := AdaptationMatrix(wref, D65, Cat16)
+	if _, ok := adaptationCache.Load(adaptationKey{wref, D65, Cat16}); ok {
+		t.Errorf("AdaptationMatrix(%v, D65, Cat16) was cached", wref)
+	}
+	if m4 := AdaptationMatrix(wref, D65, Cat16); m3 != m4 {
+		t.Errorf("Uncached AdaptationMatrix(%v, D65, Cat16) => %v, want %v", wref, m4, m3)
+	}
+}
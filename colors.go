@@ -59,7 +59,8 @@ const Delta = 1.0 / 255.0
 // This is the default reference white point.
 var D65 = [3]float64{0.95047, 1.00000, 1.08883}
 
-// And another one.
+// And another one. Note that using it as a white reference does not adapt
+// the color, use AdaptXyz for that.
 var D50 = [3]float64{0.96422, 1.00000, 0.82521}
 
 // Checks whether the color exists in RGB space, i.e. all values are in [0..1]
@@ -654,8 +655,31 @@ func (c1 Color) DistanceCIE76(c2 Color) float64 {
 }
 
 // Uses the CIE94 formula to calculate color distance. More accurate than
-// DistanceLab, but also more work.
+// DistanceLab, but also more work. This uses the graphic arts weights, see
+// DistanceCIE94Ex for others.
 func (cl Color) DistanceCIE94(cr Color) float64 {
+	return cl.DistanceCIE94Ex(cr, CIE94GraphicArts)
+}
+
+// CIE94Params are the application-dependent weights of the CIE94 formula:
+// the parametric factors KL, KC and KH, and the K1 and K2 of the chroma and
+// hue weighting functions.
+type CIE94Params struct {
+	KL, KC, KH float64
+	K1, K2     float64
+}
+
+var (
+	// CIE94GraphicArts are the weights for graphic arts, the default.
+	CIE94GraphicArts = CIE94Params{KL: 1.0, KC: 1.0, KH: 1.0, K1: 0.045, K2: 0.015}
+	// CIE94Textiles are the weights for textiles.
+	CIE94Textiles = CIE94Params{KL: 2.0, KC: 1.0, KH: 1.0, K1: 0.048, K2: 0.014}
+)
+
+// DistanceCIE94Ex uses the CIE94 formula with the given weights to calculate
+// color distance. Note that CIE94 is not symmetric: cl is the reference
+// (standard) color, whose chroma weighs the differences, and cr the sample.
+func (cl Color) DistanceCIE94Ex(cr Color, p CIE94Params) float64 {
 	l1, a1, b1 := cl.Lab()
 	l2, a2, b2 := cr.Lab()
 
@@ -666,12 +690,6 @@ func (cl Color) DistanceCIE94(cr Color) float64 {
 	l1, a1, b1 = l1*100.0, a1*100.0, b1*100.0
 	l2, a2, b2 = l2*100.0, a2*100.0, b2*100.0
 
-	kl := 1.0 // 2.0 for textiles
-	kc := 1.0
-	kh := 1.0
-	k1 := 0.045 // 0.048 for textiles
-	k2 := 0.015 // 0.014 for textiles.
-
 	deltaL := l1 - l2
 	c1 := math.Sqrt(sq(a1) + sq(b1))
 	c2 := math.Sqrt(sq(a2) + sq(b2))
@@ -680,12 +698,12 @@ func (cl Color) DistanceCIE94(cr Color) float64 {
 	// Not taking Sqrt here for stability, and it's unnecessary.
 	deltaHab2 := sq(a1-a2) + sq(b1-b2) - sq(deltaCab)
 	sl := 1.0
-	sc := 1.0 + k1*c1
-	sh := 1.0 + k2*c1
+	sc := 1.0 + p.K1*c1
+	sh := 1.0 + p.K2*c1
 
-	vL2 := sq(deltaL / (kl * sl))
-	vC2 := sq(deltaCab / (kc * sc))
-	vH2 := deltaHab2 / sq(kh*sh)
+	vL2 := sq(deltaL / (p.KL * sl))
+	vC2 := sq(deltaCab / (p.KC * sc))
+	vH2 := deltaHab2 / sq(p.KH*sh)
 
 	return math.Sqrt(vL2+vC2+vH2) * 0.01 // See above.
 }
@@ -774,6 +792,58 @@ func (cl Color) DistanceCIEDE2000klch(cr Color, kl, kc, kh float64) float64 {
 	return math.Sqrt(sq(deltaLp/(kl*sl))+sq(deltaCp/(kc*sc))+sq(deltaHp/(kh*sh))+rt*(deltaCp/(kc*sc))*(deltaHp/(kh*sh))) * 0.01
 }
 
+// DistanceCMC uses the CMC l:c (1984) formula to calculate color distance,
+// with the given lightness and chroma weights l and c. Unlike the other
+// distances, it is not symmetric: cl is the reference (standard) color, and
+// cr the sample. Use DistanceCMC21 for acceptability, and DistanceCMC11 for
+// perceptibility.
+func (cl Color) DistanceCMC(cr Color, l, c float64) float64 {
+	l1, a1, b1 := cl.Lab()
+	l2, a2, b2 := cr.Lab()
+
+	// As with CIE94, we scale up the ranges of L,a,b beforehand and scale
+	// them down again afterwards.
+	l1, a1, b1 = l1*100.0, a1*100.0, b1*100.0
+	l2, a2, b2 = l2*100.0, a2*100.0, b2*100.0
+
+	deltaL := l1 - l2
+	c1 := math.Sqrt(sq(a1) + sq(b1))
+	c2 := math.Sqrt(sq(a2) + sq(b2))
+	deltaC := c1 - c2
+	// Not taking Sqrt here for stability, and it's unnecessary.
+	deltaH2 := sq(a1-a2) + sq(b1-b2) - sq(deltaC)
+
+	h1 := math.Mod(math.Atan2(b1, a1)*180.0/math.Pi+360.0, 360.0)
+	var t float64
+	if 164.0 <= h1 && h1 <= 345.0 {
+		t = 0.56 + math.Abs(0.2*math.Cos((h1+168.0)*math.Pi/180.0))
+	} else {
+		t = 0.36 + math.Abs(0.4*math.Cos((h1+35.0)*math.Pi/180.0))
+	}
+	f := math.Sqrt(sq(sq(c1)) / (sq(sq(c1)) + 1900.0))
+
+	sl := 0.511
+	if l1 >= 16.0 {
+		sl = 0.040975 * l1 / (1.0 + 0.01765*l1)
+	}
+	sc := 0.0638*c1/(1.0+0.0131*c1) + 0.638
+	sh := sc * (f*t + 1.0 - f)
+
+	return math.Sqrt(sq(deltaL/(l*sl))+sq(deltaC/(c*sc))+deltaH2/sq(sh)) * 0.01
+}
+
+// DistanceCMC21 is the CMC 2:1 color distance, which is the one commonly used
+// in the textile industry for deciding whether a color match is acceptable.
+func (cl Color) DistanceCMC21(cr Color) float64 {
+	return cl.DistanceCMC(cr, 2.0, 1.0)
+}
+
+// DistanceCMC11 is the CMC 1:1 color distance, for deciding whether a color
+// difference is perceptible at all.
+func (cl Color) DistanceCMC11(cr Color) float64 {
+	return cl.DistanceCMC(cr, 1.0, 1.0)
+}
+
 // BlendLab blends two colors in the L*a*b* color-space, which should result in a smoother blend.
 // t == 0 results in c1, t == 1 results in c2
 func (c1 Color) BlendLab(c2 Color, t float64) Color {
@@ -1028,3 +1098,124 @@ func (col1 Color) BlendLuvLCh(col2 Color, t float64) Color {
 	// We know that h are both in [0..360]
 	return LuvLCh(l1+t*(l2-l1), c1+t*(c2-c1), interp_angle(h1, h2, t))
 }
+
+/// OkLab ///
+/////////////
+// https://bottosson.github.io/posts/oklab/
+// OkLab is a perceptual color space designed to predict lightness, chroma and
+// hue better than CIE L*a*b*, in particular for blues. Its L is already in
+// [0..1] and a, b are roughly in [-0.4..0.4], so no rescaling is needed to fit
+// in with the rest of this library.
+
+// XyzToOkLab converts from CIE XYZ-space (D65) to OkLab space.
+func XyzToOkLab(x, y, z float64) (l, a, b float64) {
+	// The first matrix is Ottosson's linear sRGB to LMS matrix combined with
+	// our XyzToLinearRgb, so that OkLab is consistent with the rest of the library.
+	l_ := math.Cbrt(0.8190224432164313*x + 0.3619062562801225*y - 0.1288737826121641*z)
+	m_ := math.Cbrt(0.03298366719802673*x + 0.9292868468965551*y + 0.03614466816999848*z)
+	s_ := math.Cbrt(0.04817719956604608*x + 0.2642395249442278*y + 0.6335478258136937*z)
+	l = 0.2104542553*l_ + 0.7936177850*m_ - 0.0040720468*s_
+	a = 1.9779984951*l_ - 2.4285922050*m_ + 0.4505937099*s_
+	b = 0.0259040371*l_ + 0.7827717662*m_ - 0.8086757660*s_
+	return
+}
+
+// OkLabToXyz converts from OkLab space to CIE XYZ-space (D65).
+func OkLabToXyz(l, a, b float64) (x, y, z float64) {
+	l_ := cub(l + 0.3963377774*a + 0.2158037573*b)
+	m_ := cub(l - 0.1055613458*a - 0.0638541728*b)
+	s_ := cub(l - 0.0894841775*a - 1.2914855480*b)
+	x = 1.226879873546334*l_ - 0.5578149968105383*m_ + 0.2813910503158762*s_
+	y = -0.04057576281012838*l_ + 1.112286829502323*m_ - 0.07171106669219467*s_
+	z = -0.07637294956284039*l_ - 0.4214933241566379*m_ + 1.586924024479357*s_
+	return
+}
+
+// OkLab converts the given color to OkLab space.
+// L is in [0..1] and both a and b are in about [-0.4..0.4]
+func (col Color) OkLab() (l, a, b float64) {
+	return XyzToOkLab(col.Xyz())
+}
+
+// OkLab generates a color by using data given in OkLab space.
+// L is in [0..1] and both a and b are in about [-0.4..0.4]
+// WARNING: many combinations of `l`, `a`, and `b` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func OkLab(l, a, b float64) Color {
+	return Xyz(OkLabToXyz(l, a, b))
+}
+
+// DistanceOkLab is the Euclidean distance in OkLab space. Like DistanceLab,
+// it is cheap and a good measure of visual similarity, but it is more
+// uniform across hues.
+func (c1 Color) DistanceOkLab(c2 Color) float64 {
+	l1, a1, b1 := c1.OkLab()
+	l2, a2, b2 := c2.OkLab()
+	return math.Sqrt(sq(l1-l2) + sq(a1-a2) + sq(b1-b2))
+}
+
+// BlendOkLab blends two colors in the OkLab color-space, which should result in a smoother blend.
+// t == 0 results in c1, t == 1 results in c2
+func (c1 Color) BlendOkLab(c2 Color, t float64) Color {
+	l1, a1, b1 := c1.OkLab()
+	l2, a2, b2 := c2.OkLab()
+	return OkLab(l1+t*(l2-l1),
+		a1+t*(a2-a1),
+		b1+t*(b2-b1))
+}
+
+/// OkLch ///
+/////////////
+// OkLch is nothing else than OkLab in cylindrical coordinates, just like Hcl
+// is for L*a*b*.
+
+// OkLabToOkLch converts from OkLab to its cylindrical OkLch representation.
+func OkLabToOkLch(L, a, b float64) (l, c, h float64) {
+	c = math.Sqrt(sq(a) + sq(b))
+	// The hue of grays is only floating point noise, so it's set to 0.
+	if c > 1e-4 {
+		h = math.Mod(57.29577951308232087721*math.Atan2(b, a)+360.0, 360.0) // Rad2Deg
+	}
+	l = L
+	return
+}
+
+// OkLchToOkLab converts from cylindrical OkLch back to OkLab.
+func OkLchToOkLab(l, c, h float64) (L, a, b float64) {
+	H := 0.01745329251994329576 * h // Deg2Rad
+	a = c * math.Cos(H)
+	b = c * math.Sin(H)
+	L = l
+	return
+}
+
+// OkLch converts the given color to OkLch space.
+// L is in [0..1], C is in about [0..0.4] and h in [0..360]
+func (col Color) OkLch() (l, c, h float64) {
+	return OkLabToOkLch(col.OkLab())
+}
+
+// OkLch generates a color by using data given in OkLch space.
+// L is in [0..1], C is in about [0..0.4] and h in [0..360]
+// WARNING: many combinations of `l`, `c`, and `h` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func OkLch(l, c, h float64) Color {
+	return OkLab(OkLchToOkLab(l, c, h))
+}
+
+// BlendOkLch blends two colors in the cylindrical OkLab color space.
+// t == 0 results in c1, t == 1 results in c2
+func (col1 Color) BlendOkLch(col2 Color, t float64) Color {
+	l1, c1, h1 := col1.OkLch()
+	l2, c2, h2 := col2.OkLch()
+
+	// https://github.com/lucasb-eyer/go-colorful/pull/60
+	if c1 <= 0.00015 && c2 >= 0.00015 {
+		h1 = h2
+	} else if c2 <= 0.00015 && c1 >= 0.00015 {
+		h2 = h1
+	}
+
+	// We know that h are both in [0..360]
+	return OkLch(l1+t*(l2-l1), c1+t*(c2-c1), interp_angle(h1, h2, t)).Clamped()
+}
@@ -421,6 +421,105 @@ func TestHclWhiteRefConversion(t *testing.T) {
 	}
 }
 
+/// OkLab ///
+/////////////
+
+// Ground-truth from https://bottosson.github.io/posts/oklab/#table-of-example-xyz-and-oklab-pairs
+var oklabxyzvals = []struct {
+	xyz   [3]float64
+	oklab [3]float64
+}{
+	{[3]float64{0.950, 1.000, 1.089}, [3]float64{1.000, 0.000, 0.000}},
+	{[3]float64{1.000, 0.000, 0.000}, [3]float64{0.450, 1.236, -0.019}},
+	{[3]float64{0.000, 1.000, 0.000}, [3]float64{0.922, -0.671, 0.263}},
+	{[3]float64{0.000, 0.000, 1.000}, [3]float64{0.153, -1.415, -0.449}},
+}
+
+// Ground-truth from the sRGB reference implementation in the same post.
+var oklabvals = []struct {
+	c     Color
+	oklab [3]float64
+	oklch [3]float64
+}{
+	{Color{1.0, 1.0, 1.0}, [3]float64{1.000000, 0.000000, 0.000000}, [3]float64{1.000000, 0.000000, 0.0000}},
+	{Color{1.0, 0.0, 0.0}, [3]float64{0.627955, 0.224863, 0.125846}, [3]float64{0.627955, 0.257683, 29.2339}},
+	{Color{0.0, 1.0, 0.0}, [3]float64{0.866440, -0.233888, 0.179498}, [3]float64{0.866440, 0.294827, 142.4953}},
+	{Color{0.0, 0.0, 1.0}, [3]float64{0.452014, -0.032457, -0.311528}, [3]float64{0.452014, 0.313214, 264.0520}},
+	{Color{0.0, 0.0, 0.0}, [3]float64{0.000000, 0.000000, 0.000000}, [3]float64{0.000000, 0.000000, 0.0000}},
+}
+
+func TestXyzToOkLab(t *testing.T) {
+	for i, tt := range oklabxyzvals {
+		l, a, b := XyzToOkLab(tt.xyz[0], tt.xyz[1], tt.xyz[2])
+		if math.Abs(l-tt.oklab[0]) > 2e-3 || math.Abs(a-tt.oklab[1]) > 2e-3 || math.Abs(b-tt.oklab[2]) > 2e-3 {
+			t.Errorf("%v. XyzToOkLab(%v) => (%v), want %v", i, tt.xyz, []float64{l, a, b}, tt.oklab)
+		}
+	}
+}
+
+func TestOkLabCreation(t *testing.T) {
+	for i, tt := range oklabvals {
+		c := OkLab(tt.oklab[0], tt.oklab[1], tt.oklab[2])
+		if !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. OkLab(%v) => (%v), want %v (delta %v)", i, tt.oklab, c, tt.c, delta)
+		}
+	}
+}
+
+func TestOkLabConversion(t *testing.T) {
+	for i, tt := range oklabvals {
+		l, a, b := tt.c.OkLab()
+		if !almosteq(l, tt.oklab[0]) || !almosteq(a, tt.oklab[1]) || !almosteq(b, tt.oklab[2]) {
+			t.Errorf("%v. %v.OkLab() => (%v), want %v (delta %v)", i, tt.c, []float64{l, a, b}, tt.oklab, delta)
+		}
+	}
+}
+
+func TestOkLchCreation(t *testing.T) {
+	for i, tt := range oklabvals {
+		c := OkLch(tt.oklch[0], tt.oklch[1], tt.oklch[2])
+		if !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. OkLch(%v) => (%v), want %v (delta %v)", i, tt.oklch, c, tt.c, delta)
+		}
+	}
+}
+
+func TestOkLchConversion(t *testing.T) {
+	for i, tt := range oklabvals {
+		l, c, h := tt.c.OkLch()
+		if !almosteq(l, tt.oklch[0]) || !almosteq(c, tt.oklch[1]) || !almosteq(h, tt.oklch[2]) {
+			t.Errorf("%v. %v.OkLch() => (%v), want %v (delta %v)", i, tt.c, []float64{l, c, h}, tt.oklch, delta)
+		}
+	}
+}
+
+func TestOkLabToOkLchHue(t *testing.T) {
+	tests := []struct {
+		a, b float64
+		h    float64
+	}{
+		{0.0, 0.1, 90.0},
+		{0.0, -0.1, 270.0},
+		{0.1, 0.1, 45.0},
+		{-0.1, 0.0, 180.0},
+		{0.00001, 0.00002, 0.0}, // Gray
+	}
+	for i, tt := range tests {
+		if _, _, h := OkLabToOkLch(0.5, tt.a, tt.b); !almosteq(h, tt.h) {
+			t.Errorf("%v. OkLabToOkLch(0.5, %v, %v) => hue %v, want %v", i, tt.a, tt.b, h, tt.h)
+		}
+	}
+}
+
+func TestOkLabRoundtrip(t *testing.T) {
+	for i, tt := range vals {
+		c := OkLab(tt.c.OkLab())
+		if !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. OkLab(%v.OkLab()) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+	}
+}
+
 /// Test distances ///
 //////////////////////
 
@@ -463,6 +562,28 @@ func TestCIE94Distance(t *testing.T) {
 	}
 }
 
+func TestCIE94DistanceEx(t *testing.T) {
+	// Ground-truth from colour-science.
+	c1, c2 := Lab(1.0, 0.2157210357, 2.722281935), Lab(1.0, 4.2667945353, 0.7239590835)
+	if d := c1.DistanceCIE94Ex(c2, CIE94GraphicArts); math.Abs(d-0.837792255) > 1e-8 {
+		t.Errorf("%v.DistanceCIE94Ex(%v, CIE94GraphicArts) => (%v), want %v", c1, c2, d, 0.837792255)
+	}
+	if d := c1.DistanceCIE94Ex(c2, CIE94Textiles); math.Abs(d-0.883355530) > 1e-8 {
+		t.Errorf("%v.DistanceCIE94Ex(%v, CIE94Textiles) => (%v), want %v", c1, c2, d, 0.883355530)
+	}
+
+	// CIE94 is not symmetric, the first color is the reference.
+	if d1, d2 := c1.DistanceCIE94Ex(c2, CIE94Textiles), c2.DistanceCIE94Ex(c1, CIE94Textiles); almosteq(d1, d2) {
+		t.Errorf("DistanceCIE94Ex is symmetric: %v and %v", d1, d2)
+	}
+
+	for i, tt := range dists {
+		if d, want := tt.c1.DistanceCIE94(tt.c2), tt.c1.DistanceCIE94Ex(tt.c2, CIE94GraphicArts); d != want {
+			t.Errorf("%v. %v.DistanceCIE94(%v) => (%v), want %v", i, tt.c1, tt.c2, d, want)
+		}
+	}
+}
+
 func TestCIEDE2000Distance(t *testing.T) {
 	for i, tt := range dists {
 		d := tt.c1.DistanceCIEDE2000(tt.c2)
@@ -472,6 +593,58 @@ func TestCIEDE2000Distance(t *testing.T) {
 	}
 }
 
+// Reference values, in the /100 scale of this library, from
+//   - colour-science, colour/difference/tests/test_delta_e.py, with l = 2 and c = 1.
+//   - python-colormath, colormath/tests/test_color_diff.py, to three decimals.
+var cmcdists = []struct {
+	c1, c2 Color
+	l      float64
+	want   float64
+	tol    float64
+}{
+	{Lab(1.0, 0.2157210357, 2.722281935), Lab(1.0, 4.2667945353, 0.7239590835), 2.0, 1.7270477129, 1e-10},
+	{Lab(1.0, 0.2157210357, 2.722281935), Lab(1.0, 0.0832281957, -0.7358297716), 2.0, 1.2171841479, 1e-10},
+	{Lab(0.4899183622, -0.0010561667, 4.0065619925), Lab(0.5065907324, -0.0011671910, 4.0282235718), 2.0, 0.00899699975683419, 1e-10},
+	{Lab(0.009, 0.163, -0.0222), Lab(0.007, 0.142, -0.0180), 2.0, 0.01443, 5e-6},
+	{Lab(0.009, 0.163, -0.0222), Lab(0.007, 0.142, -0.0180), 1.0, 0.01482, 5e-6},
+}
+
+func TestCMCDistance(t *testing.T) {
+	for i, tt := range cmcdists {
+		if d := tt.c1.DistanceCMC(tt.c2, tt.l, 1.0); math.Abs(d-tt.want) > tt.tol {
+			t.Errorf("%v. %v.DistanceCMC(%v, %v, 1) => (%v), want %v", i, tt.c1, tt.c2, tt.l, d, tt.want)
+		}
+
+		d, name := tt.c1.DistanceCMC21(tt.c2), "DistanceCMC21"
+		if tt.l == 1.0 {
+			d, name = tt.c1.DistanceCMC11(tt.c2), "DistanceCMC11"
+		}
+		if math.Abs(d-tt.want) > tt.tol {
+			t.Errorf("%v. %v.%v(%v) => (%v), want %v", i, tt.c1, name, tt.c2, d, tt.want)
+		}
+	}
+
+	for i, tt := range dists {
+		if d := tt.c1.DistanceCMC21(tt.c1); d != 0 {
+			t.Errorf("%v. %v.DistanceCMC21(%v) => (%v), want 0", i, tt.c1, tt.c1, d)
+		}
+		if d, want := tt.c1.DistanceCMC11(tt.c2), tt.c1.DistanceCMC(tt.c2, 1, 1); d != want {
+			t.Errorf("%v. %v.DistanceCMC11(%v) => (%v), want %v", i, tt.c1, tt.c2, d, want)
+		}
+	}
+
+	// With only a lightness difference, the l weight divides the distance.
+	c1, c2 := Lab(0.8, 0.2, 0.1), Lab(0.5, 0.2, 0.1)
+	if d21, d11 := c1.DistanceCMC21(c2), c1.DistanceCMC11(c2); !almosteq(2*d21, d11) {
+		t.Errorf("%v.DistanceCMC21(%v) => (%v), want half of DistanceCMC11 %v", c1, c2, d21, d11)
+	}
+
+	// CMC is not symmetric, the first color is the reference.
+	if d1, d2 := c1.DistanceCMC21(Lab(0.8, -0.3, 0.4)), Lab(0.8, -0.3, 0.4).DistanceCMC21(c1); almosteq(d1, d2) {
+		t.Errorf("DistanceCMC21 is symmetric: %v and %v", d1, d2)
+	}
+}
+
 /// Test utilities ///
 //////////////////////
 
@@ -593,6 +766,42 @@ func TestIssue11(t *testing.T) {
 	if blend != c2hex {
 		t.Errorf("Issue11: %v --LuvLCh-> %v = %v, want %v", c1hex, c2hex, blend, c2hex)
 	}
+
+	blend = c1.BlendOkLab(c2, 0).Hex()
+	if blend != c1hex {
+		t.Errorf("Issue11: %v --OkLab-> %v = %v, want %v", c1hex, c2hex, blend, c1hex)
+	}
+	blend = c1.BlendOkLab(c2, 1).Hex()
+	if blend != c2hex {
+		t.Errorf("Issue11: %v --OkLab-> %v = %v, want %v", c1hex, c2hex, blend, c2hex)
+	}
+
+	blend = c1.BlendOkLch(c2, 0).Hex()
+	if blend != c1hex {
+		t.Errorf("Issue11: %v --OkLch-> %v = %v, want %v", c1hex, c2hex, blend, c1hex)
+	}
+	blend = c1.BlendOkLch(c2, 1).Hex()
+	if blend != c2hex {
+		t.Errorf("Issue11: %v --OkLch-> %v = %v, want %v", c1hex, c2hex, blend, c2hex)
+	}
+
+	blend = c1.BlendCam16Ucs(c2, 0).Hex()
+	if blend != c1hex {
+		t.Errorf("Issue11: %v --Cam16Ucs-> %v = %v, want %v", c1hex, c2hex, blend, c1hex)
+	}
+	blend = c1.BlendCam16Ucs(c2, 1).Hex()
+	if blend != c2hex {
+		t.Errorf("Issue11: %v --Cam16Ucs-> %v = %v, want %v", c1hex, c2hex, blend, c2hex)
+	}
+
+	blend = c1.BlendPigment(c2, 0).Hex()
+	if blend != c1hex {
+		t.Errorf("Issue11: %v --Pigment-> %v = %v, want %v", c1hex, c2hex, blend, c1hex)
+	}
+	blend = c1.BlendPigment(c2, 1).Hex()
+	if blend != c2hex {
+		t.Errorf("Issue11: %v --Pigment-> %v = %v, want %v", c1hex, c2hex, blend, c2hex)
+	}
 }
 
 // For testing angular interpolation internal function
@@ -0,0 +1,107 @@
+package colorful
+
+import "math"
+
+// Compositing puts a color with alpha, the source, onto another one, the
+// destination or backdrop, as when drawing one layer of an image onto another.
+//
+// Source: Porter and Duff, "Compositing Digital Images", SIGGRAPH 1984, and
+// https://www.w3.org/TR/compositing-1/#porterduffcompositingoperators
+
+// A CompositeOp is one of the Porter-Duff compositing operators. Each one
+// keeps the parts of source and destination which are covered by just one or
+// both of them differently.
+type CompositeOp int
+
+const (
+	// Neither source nor destination, the result is transparent.
+	OpClear CompositeOp = iota
+	// Only the source.
+	OpSrc
+	// Only the destination.
+	OpDst
+	// The source over the destination, i.e. normal drawing.
+	OpSrcOver
+	// The destination over the source.
+	OpDstOver
+	// The source where the destination is.
+	OpSrcIn
+	// The destination where the source is.
+	OpDstIn
+	// The source where the destination isn't.
+	OpSrcOut
+	// The destination where the source isn't.
+	OpDstOut
+	// The source where the destination is, over the destination.
+	OpSrcAtop
+	// The destination where the source is, over the source.
+	OpDstAtop
+	// The source where the destination isn't, and the destination where the source isn't.
+	OpXor
+	// The sum of source and destination, clamped, "lighter" in CSS.
+	OpPlus
+)
+
+// factors returns the Porter-Duff fractions of source and destination to keep,
+// given their alphas.
+func (op CompositeOp) factors(as, ad float64) (fs, fd float64) {
+	switch op {
+	case OpSrc:
+		return 1, 0
+	case OpDst:
+		return 0, 1
+	case OpSrcOver:
+		return 1, 1 - as
+	case OpDstOver:
+		return 1 - ad, 1
+	case OpSrcIn:
+		return ad, 0
+	case OpDstIn:
+		return 0, as
+	case OpSrcOut:
+		return 1 - ad, 0
+	case OpDstOut:
+		return 0, 1 - as
+	case OpSrcAtop:
+		return ad, 1 - as
+	case OpDstAtop:
+		return 1 - ad, as
+	case OpXor:
+		return 1 - ad, 1 - as
+	case OpPlus:
+		return 1, 1
+	}
+	return 0, 0
+}
+
+// composite composites premultiplied colors.
+func (op CompositeOp) composite(rs, gs, bs, as, rd, gd, bd, ad float64) (r, g, b, a float64) {
+	fs, fd := op.factors(as, ad)
+	r, g, b, a = rs*fs+rd*fd, gs*fs+gd*fd, bs*fs+bd*fd, as*fs+ad*fd
+	if op == OpPlus {
+		r, g, b, a = math.Min(r, 1), math.Min(g, 1), math.Min(b, 1), math.Min(a, 1)
+	}
+	return
+}
+
+// Composite puts src onto dst using the given operator, in sRGB like web
+// browsers do. See CompositeLinear for the physically correct way.
+func (src ColorA) Composite(dst ColorA, op CompositeOp) ColorA {
+	rs, gs, bs, as := src.Premultiplied()
+	rd, gd, bd, ad := dst.Premultiplied()
+	return Premultiplied(op.composite(rs, gs, bs, as, rd, gd, bd, ad))
+}
+
+// CompositeLinear puts src onto dst using the given operator, in linear RGB.
+// This is how light adds up, so it's the correct way, e.g. a half transparent
+// white over black looks as bright as a 50% dithering of the two.
+func (src ColorA) CompositeLinear(dst ColorA, op CompositeOp) ColorA {
+	rs, gs, bs := src.LinearRgb()
+	rd, gd, bd := dst.LinearRgb()
+	as, ad := src.A, dst.A
+	r, g, b, a := op.composite(rs*as, gs*as, bs*as, as, rd*ad, gd*ad, bd*ad, ad)
+	if a == 0 {
+		return ColorA{}
+	}
+	return ColorA{LinearRgb(r/a, g/a, b/a), a}
+}
@@ -0,0 +1,67 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComposite(t *testing.T) {
+	src := ColorA{Color{1.0, 0.0, 0.0}, 0.5}
+	dst := ColorA{Color{0.0, 0.0, 1.0}, 0.75}
+
+	// Worked out by hand from the Porter-Duff table, premultiplied:
+	// src is (0.5, 0, 0, 0.5) and dst is (0, 0, 0.75, 0.75).
+	tests := []struct {
+		op         CompositeOp
+		r, g, b, a float64
+	}{
+		{OpClear, 0, 0, 0, 0},
+		{OpSrc, 0.5, 0, 0, 0.5},
+		{OpDst, 0, 0, 0.75, 0.75},
+		{OpSrcOver, 0.5, 0, 0.375, 0.875},
+		{OpDstOver, 0.125, 0, 0.75, 0.875},
+		{OpSrcIn, 0.375, 0, 0, 0.375},
+		{OpDstIn, 0, 0, 0.375, 0.375},
+		{OpSrcOut, 0.125, 0, 0, 0.125},
+		{OpDstOut, 0, 0, 0.375, 0.375},
+		{OpSrcAtop, 0.375, 0, 0.375, 0.75},
+		{OpDstAtop, 0.125, 0, 0.375, 0.5},
+		{OpXor, 0.125, 0, 0.375, 0.5},
+		{OpPlus, 0.5, 0, 0.75, 1},
+	}
+	for _, tt := range tests {
+		c := src.Composite(dst, tt.op)
+		r, g, b, a := c.Premultiplied()
+		if math.Abs(r-tt.r) > 1e-12 || math.Abs(g-tt.g) > 1e-12 || math.Abs(b-tt.b) > 1e-12 || math.Abs(a-tt.a) > 1e-12 {
+			t.Errorf("%v.Composite(%v, %v) => %v premultiplied, want %v", src, dst, tt.op, []float64{r, g, b, a}, []float64{tt.r, tt.g, tt.b, tt.a})
+		}
+
+		// The alpha doesn't depend on the space.
+		if lin := src.CompositeLinear(dst, tt.op); math.Abs(lin.A-tt.a) > 1e-12 {
+			t.Errorf("%v.CompositeLinear(%v, %v) => %v, want alpha %v", src, dst, tt.op, lin, tt.a)
+		}
+	}
+}
+
+func TestCompositeLinear(t *testing.T) {
+	white := ColorA{Color{1, 1, 1}, 0.5}
+	black := ColorA{Color{0, 0, 0}, 1}
+
+	// Browsers blend the sRGB values, light blends linearly.
+	if c := white.Composite(black, OpSrcOver); !c.AlmostEqualRgba(ColorA{Color{0.5, 0.5, 0.5}, 1}) {
+		t.Errorf("%v.Composite(%v, OpSrcOver) => %v, want 50%% gray", white, black, c)
+	}
+	want := ColorA{LinearRgb(0.5, 0.5, 0.5), 1}
+	if c := white.CompositeLinear(black, OpSrcOver); !c.AlmostEqualRgba(want) {
+		t.Errorf("%v.CompositeLinear(%v, OpSrcOver) => %v, want %v", white, black, c, want)
+	}
+
+	// Opaque over anything is itself, and nothing is left of xor-ing opaque colors.
+	red := ColorA{Color{1, 0, 0}, 1}
+	if c := red.CompositeLinear(white, OpSrcOver); !c.AlmostEqualRgba(red) {
+		t.Errorf("%v.CompositeLinear(%v, OpSrcOver) => %v, want %v", red, white, c, red)
+	}
+	if c := red.CompositeLinear(black, OpXor); c != (ColorA{}) {
+		t.Errorf("%v.CompositeLinear(%v, OpXor) => %v, want transparent", red, black, c)
+	}
+}
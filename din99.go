@@ -0,0 +1,90 @@
+package colorful
+
+import "math"
+
+// DIN99o is a logarithmic compression and rotation of CIE-L*a*b* (DIN 6176),
+// made such that the Euclidean distance in it is about as good as
+// DistanceCIEDE2000, while being much cheaper to compute. That also makes it
+// suitable for spatial indexes, which need a metric.
+//
+// Source: DIN 6176:2001, and Cui et al., "Uniform colour spaces based on the
+// DIN99 colour-difference formula", Color Research & Application 27(4), 2002.
+//
+// Like Lab in this library, its values are scaled by 1/100, so L99o is in [0..1].
+
+const (
+	din99oL    = 303.67
+	din99oLF   = 0.0039
+	din99oAng  = 26.0 * math.Pi / 180.0
+	din99oFb   = 0.83
+	din99oCF   = 0.075
+	din99oCDiv = 0.0435
+)
+
+// LabToDin99o converts from CIE-L*a*b* to DIN99o, both scaled by 1/100 like
+// everywhere in this library.
+func LabToDin99o(l, a, b float64) (l99, a99, b99 float64) {
+	// The formula is in terms of the usual 0..100 range.
+	l, a, b = l*100.0, a*100.0, b*100.0
+
+	l99 = din99oL * math.Log(1.0+din99oLF*l)
+
+	sin, cos := math.Sincos(din99oAng)
+	e := a*cos + b*sin
+	f := din99oFb * (b*cos - a*sin)
+	g := math.Sqrt(e*e + f*f)
+	if g == 0 {
+		return l99 * 0.01, 0.0, 0.0
+	}
+
+	c99 := math.Log(1.0+din99oCF*g) / din99oCDiv
+	// Equivalent to rotating the hue angle atan2(f, e) back by 26°.
+	a99 = c99 * (e*cos - f*sin) / g
+	b99 = c99 * (e*sin + f*cos) / g
+	return l99 * 0.01, a99 * 0.01, b99 * 0.01
+}
+
+// Din99oToLab converts from DIN99o to CIE-L*a*b*, both scaled by 1/100 like
+// everywhere in this library.
+func Din99oToLab(l99, a99, b99 float64) (l, a, b float64) {
+	l99, a99, b99 = l99*100.0, a99*100.0, b99*100.0
+
+	l = (math.Exp(l99/din99oL) - 1.0) / din99oLF
+
+	c99 := math.Sqrt(a99*a99 + b99*b99)
+	if c99 == 0 {
+		return l * 0.01, 0.0, 0.0
+	}
+
+	g := (math.Exp(din99oCDiv*c99) - 1.0) / din99oCF
+	sin, cos := math.Sincos(din99oAng)
+	// The direction of (e, f), i.e. the hue rotated by 26°.
+	e := g * (a99*cos + b99*sin) / c99
+	f := g * (b99*cos - a99*sin) / c99 / din99oFb
+
+	a = e*cos - f*sin
+	b = e*sin + f*cos
+	return l * 0.01, a * 0.01, b * 0.01
+}
+
+// Din99o converts the given color to DIN99o, using D65 as reference white.
+func (col Color) Din99o() (l, a, b float64) {
+	return LabToDin99o(col.Lab())
+}
+
+// Din99o generates a color by using data given in DIN99o space, using D65 as
+// reference white. WARNING: many combinations of `l`, `a`, and `b` values do
+// not have corresponding valid RGB values, check the FAQ in the README if
+// you're unsure.
+func Din99o(l, a, b float64) Color {
+	return Lab(Din99oToLab(l, a, b))
+}
+
+// DistanceDin99o is the DIN 6176 color difference, the Euclidean distance in
+// DIN99o space. It is close to DistanceCIEDE2000 in accuracy, but much faster,
+// and a true metric.
+func (c1 Color) DistanceDin99o(c2 Color) float64 {
+	l1, a1, b1 := c1.Din99o()
+	l2, a2, b2 := c2.Din99o()
+	return math.Sqrt(sq(l1-l2) + sq(a1-a2) + sq(b1-b2))
+}
@@ -0,0 +1,58 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+// Ground-truth from colour-science, colour/models/tests/test_din99.py, which
+// gives [45.58303137, 34.71824922, 17.61622367] as the DIN99b of this color.
+// DIN99b only differs from DIN99o (DIN 6176) in its chroma factor, 23.0
+// instead of 1/0.0435, so a and b are scaled by 1/(23.0*0.0435) here.
+func TestLabToDin99o(t *testing.T) {
+	l, a, b := LabToDin99o(0.4152787529, 0.5263858304, 0.2692317922)
+	scale := 1.0 / (23.0 * 0.0435)
+	want := [3]float64{0.4558303137, 0.3471824922 * scale, 0.1761622367 * scale}
+	if math.Abs(l-want[0]) > 1e-7 || math.Abs(a-want[1]) > 1e-7 || math.Abs(b-want[2]) > 1e-7 {
+		t.Errorf("LabToDin99o => (%v, %v, %v), want %v", l, a, b, want)
+	}
+
+	l, a, b = Din99oToLab(l, a, b)
+	if math.Abs(l-0.4152787529) > 1e-9 || math.Abs(a-0.5263858304) > 1e-9 || math.Abs(b-0.2692317922) > 1e-9 {
+		t.Errorf("Din99oToLab => (%v, %v, %v), want [0.4152787529 0.5263858304 0.2692317922]", l, a, b)
+	}
+
+	// The lightness scale is made such that Lab's white is also DIN99o's white.
+	if l, a, b := LabToDin99o(1.0, 0.0, 0.0); math.Abs(l-1.0) > 1e-5 || a != 0 || b != 0 {
+		t.Errorf("LabToDin99o(1, 0, 0) => (%v, %v, %v), want (1, 0, 0)", l, a, b)
+	}
+	if l, a, b := Din99oToLab(0.0, 0.0, 0.0); l != 0 || a != 0 || b != 0 {
+		t.Errorf("Din99oToLab(0, 0, 0) => (%v, %v, %v), want (0, 0, 0)", l, a, b)
+	}
+}
+
+func TestDin99oRoundtrip(t *testing.T) {
+	for i, tt := range vals {
+		if c := Din99o(tt.c.Din99o()); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Din99o(%v.Din99o()) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+	}
+}
+
+func TestDistanceDin99o(t *testing.T) {
+	for i, tt := range dists {
+		if d := tt.c1.DistanceDin99o(tt.c1); d != 0 {
+			t.Errorf("%v. %v.DistanceDin99o(%v) => (%v), want 0", i, tt.c1, tt.c1, d)
+		}
+		if d1, d2 := tt.c1.DistanceDin99o(tt.c2), tt.c2.DistanceDin99o(tt.c1); d1 != d2 {
+			t.Errorf("%v. DistanceDin99o is not symmetric: %v and %v", i, d1, d2)
+		}
+	}
+
+	// Like CIEDE2000, and unlike CIE76, it compresses large chroma differences.
+	c1, c2, c3 := Lab(0.5, 0.0, 0.0), Lab(0.5, 0.1, 0.0), Lab(0.5, 0.6, 0.0)
+	near, far := c1.DistanceDin99o(c2), c2.DistanceDin99o(c3)
+	if far/near >= c2.DistanceLab(c3)/c1.DistanceLab(c2) {
+		t.Errorf("DistanceDin99o does not compress chroma: %v and %v", near, far)
+	}
+}
@@ -0,0 +1,49 @@
+package colorful
+
+// A DistanceFunc computes the distance between two colors. All the Distance
+// methods of Color can be used as one through method expressions, e.g.
+// Color.DistanceCIE94, and the DistanceFunc variables below provide them
+// for convenience. Parametrized ones like DistanceCIE94Ex need a closure:
+//
+//	dist := func(c1, c2 colorful.Color) float64 {
+//	    return c1.DistanceCIE94Ex(c2, colorful.CIE94Textiles)
+//	}
+//
+// Note that some of them, like DistanceCIE94, DistanceCIEDE2000 and
+// DistanceCMC, are not symmetric; the first color is the reference.
+type DistanceFunc func(c1, c2 Color) float64
+
+// The Distance methods of Color, as DistanceFunc.
+var (
+	DistanceFuncRgb       DistanceFunc = Color.DistanceRgb
+	DistanceFuncLinearRgb DistanceFunc = Color.DistanceLinearRgb
+	DistanceFuncRiemersma DistanceFunc = Color.DistanceRiemersma
+	DistanceFuncLab       DistanceFunc = Color.DistanceLab
+	DistanceFuncLuv       DistanceFunc = Color.DistanceLuv
+	DistanceFuncCIE76     DistanceFunc = Color.DistanceCIE76
+	DistanceFuncCIE94     DistanceFunc = Color.DistanceCIE94
+	DistanceFuncCIEDE2000 DistanceFunc = Color.DistanceCIEDE2000
+	DistanceFuncCMC21     DistanceFunc = Color.DistanceCMC21
+	DistanceFuncCMC11     DistanceFunc = Color.DistanceCMC11
+	DistanceFuncDin99o    DistanceFunc = Color.DistanceDin99o
+	DistanceFuncOkLab     DistanceFunc = Color.DistanceOkLab
+	DistanceFuncHSLuv     DistanceFunc = Color.DistanceHSLuv
+	DistanceFuncHPLuv     DistanceFunc = Color.DistanceHPLuv
+	DistanceFuncCam16Ucs  DistanceFunc = Color.DistanceCam16Ucs
+	DistanceFuncJz        DistanceFunc = Color.DistanceJz
+	DistanceFuncITP       DistanceFunc = Color.DistanceITP
+)
+
+// Nearest returns the index of the color in palette which is closest to col
+// according to dist, and that distance. It returns -1 for an empty palette.
+// col is passed as the first color to dist. This is a linear scan, see
+// Palette for an index over large palettes.
+func (col Color) Nearest(palette []Color, dist DistanceFunc) (idx int, d float64) {
+	idx = -1
+	for i, c := range palette {
+		if di := dist(col, c); idx < 0 || di < d {
+			idx, d = i, di
+		}
+	}
+	return
+}
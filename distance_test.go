@@ -0,0 +1,41 @@
+package colorful
+
+import "testing"
+
+func TestDistanceFuncs(t *testing.T) {
+	funcs := []struct {
+		name string
+		f    DistanceFunc
+		want func(c1, c2 Color) float64
+	}{
+		{"Rgb", DistanceFuncRgb, func(c1, c2 Color) float64 { return c1.DistanceRgb(c2) }},
+		{"CIE94", DistanceFuncCIE94, func(c1, c2 Color) float64 { return c1.DistanceCIE94(c2) }},
+		{"CIEDE2000", DistanceFuncCIEDE2000, func(c1, c2 Color) float64 { return c1.DistanceCIEDE2000(c2) }},
+		{"OkLab", DistanceFuncOkLab, func(c1, c2 Color) float64 { return c1.DistanceOkLab(c2) }},
+	}
+	for _, ff := range funcs {
+		for i, tt := range dists {
+			// Order matters for the non-symmetric ones.
+			if d, want := ff.f(tt.c1, tt.c2), ff.want(tt.c1, tt.c2); d != want {
+				t.Errorf("%v. DistanceFunc%v(%v, %v) => (%v), want %v", i, ff.name, tt.c1, tt.c2, d, want)
+			}
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	palette := []Color{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 1}}
+
+	if idx, d := (Color{0.9, 0.1, 0.1}).Nearest(palette, DistanceFuncCIEDE2000); idx != 0 || d <= 0 {
+		t.Errorf("Nearest => (%v, %v), want index 0", idx, d)
+	}
+	if idx, d := (Color{0, 0, 1}).Nearest(palette, DistanceFuncLab); idx != 2 || d != 0 {
+		t.Errorf("Nearest of a palette color => (%v, %v), want (2, 0)", idx, d)
+	}
+	if idx, _ := (Color{0.8, 0.8, 0.8}).Nearest(palette, Color.DistanceOkLab); idx != 3 {
+		t.Errorf("Nearest => %v, want 3", idx)
+	}
+	if idx, _ := (Color{0.8, 0.8, 0.8}).Nearest(nil, DistanceFuncLab); idx != -1 {
+		t.Errorf("Nearest in empty palette => %v, want -1", idx)
+	}
+}
@@ -10,31 +10,6 @@ import (
 	"github.com/lucasb-eyer/go-colorful"
 )
 
-// This table contains the "keypoints" of the colorgradient you want to generate.
-// The position of each keypoint has to live in the range [0,1]
-type GradientTable []struct {
-	Col colorful.Color
-	Pos float64
-}
-
-// This is the meat of the gradient computation. It returns a HCL-blend between
-// the two colors around `t`.
-// Note: It relies heavily on the fact that the gradient keypoints are sorted.
-func (gt GradientTable) GetInterpolatedColorFor(t float64) colorful.Color {
-	for i := 0; i < len(gt)-1; i++ {
-		c1 := gt[i]
-		c2 := gt[i+1]
-		if c1.Pos <= t && t <= c2.Pos {
-			// We are in between c1 and c2. Go blend them!
-			t := (t - c1.Pos) / (c2.Pos - c1.Pos)
-			return c1.Col.BlendHcl(c2.Col, t).Clamped()
-		}
-	}
-
-	// Nothing found? Means we're at (or past) the last gradient keypoint.
-	return gt[len(gt)-1].Col
-}
-
 // This is a very nice thing Golang forces you to do!
 // It is necessary so that we can write out the literal of the colortable below.
 func MustParseHex(s string) colorful.Color {
@@ -46,19 +21,22 @@ func MustParseHex(s string) colorful.Color {
 }
 
 func main() {
-	// The "keypoints" of the gradient.
-	keypoints := GradientTable{
-		{MustParseHex("#9e0142"), 0.0},
-		{MustParseHex("#d53e4f"), 0.1},
-		{MustParseHex("#f46d43"), 0.2},
-		{MustParseHex("#fdae61"), 0.3},
-		{MustParseHex("#fee090"), 0.4},
-		{MustParseHex("#ffffbf"), 0.5},
-		{MustParseHex("#e6f598"), 0.6},
-		{MustParseHex("#abdda4"), 0.7},
-		{MustParseHex("#66c2a5"), 0.8},
-		{MustParseHex("#3288bd"), 0.9},
-		{MustParseHex("#5e4fa2"), 1.0},
+	// The "keypoints" of the gradient, blended in HCL space.
+	keypoints, err := colorful.NewGradient(colorful.GradientHcl,
+		colorful.GradientStop{Color: MustParseHex("#9e0142"), Pos: 0.0},
+		colorful.GradientStop{Color: MustParseHex("#d53e4f"), Pos: 0.1},
+		colorful.GradientStop{Color: MustParseHex("#f46d43"), Pos: 0.2},
+		colorful.GradientStop{Color: MustParseHex("#fdae61"), Pos: 0.3},
+		colorful.GradientStop{Color: MustParseHex("#fee090"), Pos: 0.4},
+		colorful.GradientStop{Color: MustParseHex("#ffffbf"), Pos: 0.5},
+		colorful.GradientStop{Color: MustParseHex("#e6f598"), Pos: 0.6},
+		colorful.GradientStop{Color: MustParseHex("#abdda4"), Pos: 0.7},
+		colorful.GradientStop{Color: MustParseHex("#66c2a5"), Pos: 0.8},
+		colorful.GradientStop{Color: MustParseHex("#3288bd"), Pos: 0.9},
+		colorful.GradientStop{Color: MustParseHex("#5e4fa2"), Pos: 1.0},
+	)
+	if err != nil {
+		panic("Error creating the gradient: " + err.Error())
 	}
 
 	h := 1024
@@ -73,7 +51,7 @@ func main() {
 	img := image.NewRGBA(image.Rect(0, 0, w, h))
 
 	for y := h - 1; y >= 0; y-- {
-		c := keypoints.GetInterpolatedColorFor(float64(y) / float64(h))
+		c := keypoints.At(float64(y) / float64(h))
 		draw.Draw(img, image.Rect(0, y, w, y+1), &image.Uniform{c}, image.Point{}, draw.Src)
 	}
 
@@ -0,0 +1,298 @@
+package colorful
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// A GradientStop is a color at a position in [0..1] of a Gradient.
+type GradientStop struct {
+	Color Color
+	Pos   float64
+}
+
+// A Gradient is a smooth transition through a list of colors, the stops,
+// blending between the two neighboring stops in a color space of choice.
+// It can be (de)serialized to and from JSON and YAML like
+//
+//	{"space": "hcl", "stops": [{"color": "#9e0142", "pos": 0}, {"color": "#5e4fa2", "pos": 1}]}
+//
+// The zero Gradient has no stops and is black everywhere.
+type Gradient struct {
+	stops   []GradientStop
+	space   GradientSpace
+	interp  GradientInterpolation
+	correct bool
+
+	// The stops in space coordinates and the runs between hard transitions,
+	// only for splines and lightness correction, see prepareSpline.
+	points           [][3]float64
+	second           [][3]float64
+	runStart, runEnd []int
+}
+
+// GradientSettings are the settings of a Gradient besides its stops.
+type GradientSettings struct {
+	// The color space to blend in.
+	Space GradientSpace
+
+	// How to go from stop to stop. All but InterpolationLinear need one of
+	// GradientLab, GradientOkLab or GradientHcl as Space.
+	Interpolation GradientInterpolation
+
+	// Whether to make the lightness change linearly from the first to the
+	// last stop, so that the gradient can be used for data without the
+	// lightness of the stops distorting it. Also needs one of GradientLab,
+	// GradientOkLab or GradientHcl as Space, and it is their lightness.
+	CorrectLightness bool
+}
+
+// A GradientSpace is the color space a Gradient blends in, one for each of
+// the Blend methods of Color.
+type GradientSpace int
+
+const (
+	GradientRgb GradientSpace = iota
+	GradientLinearRgb
+	GradientHsv
+	GradientLab
+	GradientLuv
+	GradientHcl
+	GradientLuvLCh
+	GradientOkLab
+	GradientOkLch
+	GradientCam16Ucs
+	GradientPigment
+)
+
+var gradientSpaces = [...]struct {
+	name  string
+	blend func(c1, c2 Color, t float64) Color
+}{
+	GradientRgb:       {"rgb", Color.BlendRgb},
+	GradientLinearRgb: {"linear-rgb", Color.BlendLinearRgb},
+	GradientHsv:       {"hsv", Color.BlendHsv},
+	GradientLab:       {"lab", Color.BlendLab},
+	GradientLuv:       {"luv", Color.BlendLuv},
+	GradientHcl:       {"hcl", Color.BlendHcl},
+	GradientLuvLCh:    {"luv-lch", Color.BlendLuvLCh},
+	GradientOkLab:     {"oklab", Color.BlendOkLab},
+	GradientOkLch:     {"oklch", Color.BlendOkLch},
+	GradientCam16Ucs:  {"cam16-ucs", Color.BlendCam16Ucs},
+	GradientPigment:   {"pigment", Color.BlendPigment},
+}
+
+func (s GradientSpace) valid() bool {
+	return 0 <= s && int(s) < len(gradientSpaces)
+}
+
+// String returns the name of the space, as used in JSON and YAML.
+func (s GradientSpace) String() string {
+	if !s.valid() {
+		return fmt.Sprintf("GradientSpace(%d)", int(s))
+	}
+	return gradientSpaces[s].name
+}
+
+// ParseGradientSpace returns the space of the given name, as returned by String.
+func ParseGradientSpace(name string) (GradientSpace, error) {
+	for s, gs := range gradientSpaces {
+		if gs.name == name {
+			return GradientSpace(s), nil
+		}
+	}
+	return 0, fmt.Errorf("gradient: unknown space %q", name)
+}
+
+// NewGradient creates a gradient blending in the given space through the
+// given stops, which are sorted by position. Stops at the same position make
+// a hard transition between them. There needs to be at least one stop, and
+// all positions have to be in [0..1].
+func NewGradient(space GradientSpace, stops ...GradientStop) (Gradient, error) {
+	return NewGradientEx(GradientSettings{Space: space}, stops...)
+}
+
+// NewGradientEx is like NewGradient, but with more settings, see GradientSettings.
+func NewGradientEx(settings GradientSettings, stops ...GradientStop) (Gradient, error) {
+	if !settings.Space.valid() {
+		return Gradient{}, fmt.Errorf("gradient: unknown space %v", settings.Space)
+	}
+	if !settings.Interpolation.valid() {
+		return Gradient{}, fmt.Errorf("gradient: unknown interpolation %v", settings.Interpolation)
+	}
+	if _, ok := settings.Space.splineSpace(); !ok && (settings.Interpolation != InterpolationLinear || settings.CorrectLightness) {
+		return Gradient{}, fmt.Errorf("gradient: splines and lightness correction need space lab, oklab or hcl, not %v", settings.Space)
+	}
+	if len(stops) == 0 {
+		return Gradient{}, fmt.Errorf("gradient: no stops")
+	}
+	for _, s := range stops {
+		if !(0.0 <= s.Pos && s.Pos <= 1.0) {
+			return Gradient{}, fmt.Errorf("gradient: stop position %v is not in [0..1]", s.Pos)
+		}
+	}
+
+	g := Gradient{
+		stops:   append([]GradientStop(nil), stops...),
+		space:   settings.Space,
+		interp:  settings.Interpolation,
+		correct: settings.CorrectLightness,
+	}
+	sort.SliceStable(g.stops, func(i, j int) bool {
+		return g.stops[i].Pos < g.stops[j].Pos
+	})
+	if g.interp != InterpolationLinear || g.correct {
+		g.prepareSpline()
+	}
+	return g, nil
+}
+
+// Stops returns the stops of the gradient, sorted by position.
+func (g Gradient) Stops() []GradientStop {
+	return append([]GradientStop(nil), g.stops...)
+}
+
+// Space returns the color space the gradient blends in.
+func (g Gradient) Space() GradientSpace {
+	return g.space
+}
+
+// Settings returns the settings the gradient was created with.
+func (g Gradient) Settings() GradientSettings {
+	return GradientSettings{Space: g.space, Interpolation: g.interp, CorrectLightness: g.correct}
+}
+
+// At returns the color of the gradient at position t in [0..1]. Before the
+// first stop and after the last one, it is the color of that stop. Colors
+// outside of the sRGB gamut, which some spaces and splines can go into, are clamped.
+func (g Gradient) At(t float64) Color {
+	if len(g.stops) == 0 {
+		return Color{}
+	}
+
+	// The first stop after t, such that stops[i-1].Pos <= t < stops[i].Pos.
+	i := sort.Search(len(g.stops), func(i int) bool {
+		return g.stops[i].Pos > t
+	})
+	if i == 0 {
+		return g.stops[0].Color
+	}
+	if i == len(g.stops) {
+		return g.stops[i-1].Color
+	}
+
+	s1, s2 := g.stops[i-1], g.stops[i]
+	if g.interp == InterpolationLinear && !g.correct {
+		if t == s1.Pos {
+			// Exactly the stop, without the roundtrip through the space.
+			return s1.Color
+		}
+		return gradientSpaces[g.space].blend(s1.Color, s2.Color, (t-s1.Pos)/(s2.Pos-s1.Pos)).Clamped()
+	}
+
+	var p [3]float64
+	if g.interp == InterpolationLinear {
+		p = g.space.coords(gradientSpaces[g.space].blend(s1.Color, s2.Color, (t-s1.Pos)/(s2.Pos-s1.Pos)))
+	} else {
+		p = g.splineAt(i-1, t)
+	}
+	if g.correct {
+		first, last := 0, len(g.stops)-1
+		l, _ := g.space.splineSpace()
+		p[l] = g.points[first][l] + (t-g.stops[first].Pos)/(g.stops[last].Pos-g.stops[first].Pos)*(g.points[last][l]-g.points[first][l])
+	}
+	return g.space.fromCoords(p).Clamped()
+}
+
+// Colors returns n colors evenly spaced along the gradient, including both
+// ends. A single color is the start of the gradient, and it returns nil for
+// n <= 0.
+func (g Gradient) Colors(n int) []Color {
+	if n <= 0 {
+		return nil
+	}
+	cols := make([]Color, n)
+	for i := range cols {
+		t := 0.0
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+		cols[i] = g.At(t)
+	}
+	return cols
+}
+
+/// Serialization ///
+/////////////////////
+
+// gradientData is the serialized form of a Gradient.
+type gradientData struct {
+	Space            string             `json:"space" yaml:"space"`
+	Interpolation    string             `json:"interpolation,omitempty" yaml:"interpolation,omitempty"`
+	CorrectLightness bool               `json:"correct_lightness,omitempty" yaml:"correct_lightness,omitempty"`
+	Stops            []gradientStopData `json:"stops" yaml:"stops"`
+}
+
+type gradientStopData struct {
+	Color HexColor `json:"color" yaml:"color"`
+	Pos   float64  `json:"pos" yaml:"pos"`
+}
+
+func (g Gradient) data() gradientData {
+	d := gradientData{Space: g.space.String(), CorrectLightness: g.correct, Stops: make([]gradientStopData, len(g.stops))}
+	if g.interp != InterpolationLinear {
+		d.Interpolation = g.interp.String()
+	}
+	for i, s := range g.stops {
+		d.Stops[i] = gradientStopData{HexColor(s.Color), s.Pos}
+	}
+	return d
+}
+
+func (g *Gradient) setData(d gradientData) error {
+	space, err := ParseGradientSpace(d.Space)
+	if err != nil {
+		return err
+	}
+	interp := InterpolationLinear
+	if d.Interpolation != "" {
+		if interp, err = ParseGradientInterpolation(d.Interpolation); err != nil {
+			return err
+		}
+	}
+	stops := make([]GradientStop, len(d.Stops))
+	for i, s := range d.Stops {
+		stops[i] = GradientStop{Color(s.Color), s.Pos}
+	}
+	ng, err := NewGradientEx(GradientSettings{space, interp, d.CorrectLightness}, stops...)
+	if err != nil {
+		return err
+	}
+	*g = ng
+	return nil
+}
+
+func (g Gradient) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.data())
+}
+
+func (g *Gradient) UnmarshalJSON(data []byte) error {
+	var d gradientData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	return g.setData(d)
+}
+
+func (g Gradient) MarshalYAML() (interface{}, error) {
+	return g.data(), nil
+}
+
+func (g *Gradient) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var d gradientData
+	if err := unmarshal(&d); err != nil {
+		return err
+	}
+	return g.setData(d)
+}
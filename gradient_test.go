@@ -0,0 +1,169 @@
+package colorful
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func mustHex(s string) Color {
+	c, err := Hex(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestGradientAt(t *testing.T) {
+	red, green, blue := Color{1, 0, 0}, Color{0, 1, 0}, Color{0, 0, 1}
+	g, err := NewGradient(GradientLab, GradientStop{blue, 1.0}, GradientStop{red, 0.0}, GradientStop{green, 0.5})
+	if err != nil {
+		t.Fatalf("NewGradient => %v", err)
+	}
+
+	if stops := g.Stops(); stops[0].Color != red || stops[1].Color != green || stops[2].Color != blue {
+		t.Errorf("Stops are not sorted: %v", stops)
+	}
+
+	tests := []struct {
+		t    float64
+		want Color
+	}{
+		{-1.0, red},
+		{0.0, red},
+		{0.25, red.BlendLab(green, 0.5).Clamped()},
+		{0.5, green},
+		{0.6, green.BlendLab(blue, 0.2).Clamped()},
+		{1.0, blue},
+		{2.0, blue},
+	}
+	for _, tt := range tests {
+		if c := g.At(tt.t); !c.AlmostEqualRgb(tt.want) {
+			t.Errorf("At(%v) => %v, want %v", tt.t, c, tt.want)
+		}
+	}
+
+	if cols := g.Colors(5); len(cols) != 5 || cols[0] != red || cols[2] != green || cols[4] != blue {
+		t.Errorf("Colors(5) => %v", cols)
+	}
+	if cols := g.Colors(1); len(cols) != 1 || cols[0] != red {
+		t.Errorf("Colors(1) => %v", cols)
+	}
+	for _, n := range []int{0, -1} {
+		if cols := g.Colors(n); cols != nil {
+			t.Errorf("Colors(%v) => %v, want nil", n, cols)
+		}
+	}
+
+	if c := (Gradient{}).At(0.5); c != (Color{}) {
+		t.Errorf("Zero gradient At(0.5) => %v, want black", c)
+	}
+}
+
+func TestGradientSpaces(t *testing.T) {
+	c1, c2 := Color{0.9, 0.2, 0.1}, Color{0.1, 0.3, 0.8}
+	tests := []struct {
+		space GradientSpace
+		blend func(Color, Color, float64) Color
+	}{
+		{GradientRgb, Color.BlendRgb},
+		{GradientLinearRgb, Color.BlendLinearRgb},
+		{GradientHsv, Color.BlendHsv},
+		{GradientLab, Color.BlendLab},
+		{GradientLuv, Color.BlendLuv},
+		{GradientHcl, Color.BlendHcl},
+		{GradientLuvLCh, Color.BlendLuvLCh},
+		{GradientOkLab, Color.BlendOkLab},
+		{GradientOkLch, Color.BlendOkLch},
+		{GradientCam16Ucs, Color.BlendCam16Ucs},
+		{GradientPigment, Color.BlendPigment},
+	}
+	for _, tt := range tests {
+		g, err := NewGradient(tt.space, GradientStop{c1, 0}, GradientStop{c2, 1})
+		if err != nil {
+			t.Fatalf("NewGradient(%v) => %v", tt.space, err)
+		}
+		if c, want := g.At(0.3), tt.blend(c1, c2, 0.3).Clamped(); c != want {
+			t.Errorf("%v gradient At(0.3) => %v, want %v", tt.space, c, want)
+		}
+		if s, err := ParseGradientSpace(tt.space.String()); s != tt.space || err != nil {
+			t.Errorf("ParseGradientSpace(%q) => (%v, %v), want %v", tt.space.String(), s, err, tt.space)
+		}
+	}
+}
+
+func TestGradientHardStop(t *testing.T) {
+	red, blue := Color{1, 0, 0}, Color{0, 0, 1}
+	g, _ := NewGradient(GradientRgb, GradientStop{red, 0}, GradientStop{red, 0.5}, GradientStop{blue, 0.5}, GradientStop{blue, 1})
+	if c := g.At(0.4999); c != red {
+		t.Errorf("At(0.4999) => %v, want %v", c, red)
+	}
+	if c := g.At(0.5); c != blue {
+		t.Errorf("At(0.5) => %v, want %v", c, blue)
+	}
+}
+
+func TestGradientValidation(t *testing.T) {
+	red := Color{1, 0, 0}
+	if _, err := NewGradient(GradientLab); err == nil {
+		t.Errorf("NewGradient without stops didn't fail")
+	}
+	if _, err := NewGradient(GradientLab, GradientStop{red, 1.5}); err == nil {
+		t.Errorf("NewGradient with a stop at 1.5 didn't fail")
+	}
+	if _, err := NewGradient(GradientSpace(42), GradientStop{red, 0}); err == nil {
+		t.Errorf("NewGradient in an unknown space didn't fail")
+	}
+	if _, err := ParseGradientSpace("cmyk"); err == nil {
+		t.Errorf("ParseGradientSpace(\"cmyk\") didn't fail")
+	}
+}
+
+func TestGradientJson(t *testing.T) {
+	g, _ := NewGradient(GradientHcl, GradientStop{mustHex("#9e0142"), 0}, GradientStop{mustHex("#ffffbf"), 0.5}, GradientStop{mustHex("#5e4fa2"), 1})
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) => %v", g, err)
+	}
+	want := `{"space":"hcl","stops":[{"color":"#9e0142","pos":0},{"color":"#ffffbf","pos":0.5},{"color":"#5e4fa2","pos":1}]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal => %s, want %s", data, want)
+	}
+
+	var g2 Gradient
+	if err := json.Unmarshal(data, &g2); err != nil {
+		t.Fatalf("json.Unmarshal(%s) => %v", data, err)
+	}
+	if !reflect.DeepEqual(g2, g) {
+		t.Errorf("json.Unmarshal(json.Marshal(g)) => %v, want %v", g2, g)
+	}
+
+	for _, bad := range []string{
+		`{"space":"cmyk","stops":[{"color":"#9e0142","pos":0}]}`,
+		`{"space":"hcl","stops":[]}`,
+		`{"space":"hcl","stops":[{"color":"#9e0142","pos":2}]}`,
+		`{"space":"hcl","stops":[{"color":"red","pos":0}]}`,
+	} {
+		if err := json.Unmarshal([]byte(bad), &g2); err == nil {
+			t.Errorf("json.Unmarshal(%s) didn't fail", bad)
+		}
+	}
+}
+
+func TestGradientYaml(t *testing.T) {
+	g, _ := NewGradient(GradientOkLab, GradientStop{mustHex("#9e0142"), 0}, GradientStop{mustHex("#5e4fa2"), 1})
+	v, err := g.MarshalYAML()
+	if err != nil {
+		t.Fatalf("MarshalYAML => %v", err)
+	}
+
+	// Stand in for a YAML library, which would go through the same fields.
+	data, _ := json.Marshal(v)
+	var g2 Gradient
+	if err := g2.UnmarshalYAML(func(v interface{}) error { return json.Unmarshal(data, v) }); err != nil {
+		t.Fatalf("UnmarshalYAML => %v", err)
+	}
+	if !reflect.DeepEqual(g2, g) {
+		t.Errorf("UnmarshalYAML(MarshalYAML(g)) => %v, want %v", g2, g)
+	}
+}
@@ -0,0 +1,124 @@
+package colorful
+
+import "math"
+
+// HCT (hue, chroma, tone) is the color space of Google's Material Design 3.
+// Hue and chroma are those of CAM16, while tone is CIE L*, so that colors of
+// the same tone always have the same contrast against each other.
+//
+// Source: https://github.com/material-foundation/material-color-utilities
+//
+// Like CAM16, all values are kept in their conventional ranges: hue is in
+// [0..360], chroma is in about [0..150] and tone is in [0..100].
+//
+// In order to give exactly the same colors as Material, its slightly different
+// sRGB <-> XYZ matrices are used instead of LinearRgbToXyz and XyzToLinearRgb.
+
+func hctLinearRgbToXyz(r, g, b float64) (x, y, z float64) {
+	x = 0.41233895*r + 0.35762064*g + 0.18051042*b
+	y = 0.2126*r + 0.7152*g + 0.0722*b
+	z = 0.01932141*r + 0.11916382*g + 0.95034478*b
+	return
+}
+
+func hctXyzToLinearRgb(x, y, z float64) (r, g, b float64) {
+	r = 3.2413774792388685*x - 1.5376652402851851*y - 0.49885366846268053*z
+	g = -0.9691452513005321*x + 1.8758853451067872*y + 0.04156585616912061*z
+	b = 0.05562093689691305*x - 0.20395524564742123*y + 1.0571799111220335*z
+	return
+}
+
+// hctViewingConditions are Material's default viewing conditions: L* 50 gray
+// background, with the adapting luminance derived from it.
+var hctViewingConditions = NewViewingConditions(D65, 200.0/math.Pi*lab_finv(0.66/1.16), lab_finv(0.66/1.16), SurroundAverage, false)
+
+// Hct returns the hue [0..360], chroma and tone [0..100] of the color.
+func (col Color) Hct() (h, c, t float64) {
+	return xyzToHct(hctLinearRgbToXyz(col.LinearRgb()))
+}
+
+// xyzToHct converts from CIE XYZ, made with hctLinearRgbToXyz, to HCT.
+func xyzToHct(x, y, z float64) (h, c, t float64) {
+	cam := XyzToCam16(x, y, z, hctViewingConditions)
+	l, _, _ := XyzToLab(x, y, z)
+	return cam.H, cam.C, l * 100.0
+}
+
+// Hct creates a new Color given a hue in [0..360], a chroma and a tone in
+// [0..100]. Hue and tone are kept exactly, and if the requested chroma is not
+// available at that hue and tone, it is reduced to the highest one that still
+// fits in sRGB, so this will never output an invalid color.
+func Hct(h, c, t float64) Color {
+	h = math.Mod(math.Mod(h, 360.0)+360.0, 360.0)
+	gray := LinearRgb(hctXyzToLinearRgb(LabToXyz(t/100.0, 0, 0))).Clamped()
+	if c < 0.0001 || t < 0.0001 || t > 99.9999 {
+		return gray
+	}
+
+	y := lab_finv((t/100.0 + 0.16) / 1.16)
+	if col, ok := hctFindByJ(h, c, y); ok {
+		return col
+	}
+
+	// The chroma isn't available, find the most chromatic color that is.
+	col := gray
+	lo, hi := 0.0, c
+	for i := 0; i < 30; i++ {
+		mid := (lo + hi) / 2.0
+		if found, ok := hctFindByJ(h, mid, y); ok {
+			col = found
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return col
+}
+
+// hctFindByJ searches for the CAM16 lightness J which results in the relative
+// luminance y at the given hue and chroma. It fails if there is no such
+// color in the sRGB gamut.
+func hctFindByJ(h, c, y float64) (Color, bool) {
+	inGamut := func(x, yy, z float64) (Color, bool) {
+		r, g, b := hctXyzToLinearRgb(x, yy, z)
+		const eps = 1e-7
+		if r < -eps || g < -eps || b < -eps || r > 1+eps || g > 1+eps || b > 1+eps {
+			return Color{}, false
+		}
+		return LinearRgb(r, g, b).Clamped(), true
+	}
+
+	// Newton's method is what Material uses, and usually converges in a few
+	// steps. Its starting point is an empirical fit of J against Y.
+	j := math.Sqrt(y*100.0) * 11.0
+	for i := 0; i < 8; i++ {
+		x, yy, z := Cam16ToXyz(Cam16{J: j, C: c, H: h}, hctViewingConditions)
+		if math.IsNaN(yy) || yy <= 0 {
+			break
+		}
+		if math.Abs(yy-y) < 1e-7 {
+			return inGamut(x, yy, z)
+		}
+		j -= (yy - y) * j / (2.0 * yy)
+		if j <= 0 || math.IsNaN(j) {
+			break
+		}
+	}
+
+	// Otherwise, fall back to bisection, since Y increases with J.
+	lo, hi := 0.0, 100.0
+	for i := 0; i < 60; i++ {
+		j = (lo + hi) / 2.0
+		_, yy, _ := Cam16ToXyz(Cam16{J: j, C: c, H: h}, hctViewingConditions)
+		if yy < y {
+			lo = j
+		} else {
+			hi = j
+		}
+	}
+	x, yy, z := Cam16ToXyz(Cam16{J: j, C: c, H: h}, hctViewingConditions)
+	if math.Abs(yy-y) > 1e-5 {
+		return Color{}, false
+	}
+	return inGamut(x, yy, z)
+}
@@ -0,0 +1,89 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+// Ground-truth from material-color-utilities, rounded to three digits.
+var hctvals = []struct {
+	hex string
+	hct [3]float64
+}{
+	{"#ff0000", [3]float64{27.408, 113.357, 53.241}},
+	{"#00ff00", [3]float64{142.139, 108.410, 87.737}},
+	{"#0000ff", [3]float64{282.788, 87.230, 32.302}},
+	{"#ffffff", [3]float64{209.492, 2.869, 100.0}},
+}
+
+func TestHctConversion(t *testing.T) {
+	for i, tt := range hctvals {
+		h, c, l := fromHex(tt.hex).Hct()
+		if math.Abs(h-tt.hct[0]) > 0.01 || math.Abs(c-tt.hct[1]) > 0.01 || math.Abs(l-tt.hct[2]) > 0.01 {
+			t.Errorf("%v. %v.Hct() => (%v), want %v", i, tt.hex, []float64{h, c, l}, tt.hct)
+		}
+	}
+}
+
+func TestHctCreation(t *testing.T) {
+	for i, tt := range hctvals {
+		if c := Hct(tt.hct[0], tt.hct[1], tt.hct[2]); c.Hex() != tt.hex {
+			t.Errorf("%v. Hct(%v) => (%v), want %v", i, tt.hct, c.Hex(), tt.hex)
+		}
+	}
+}
+
+func TestHctRoundtrip(t *testing.T) {
+	for i, tt := range vals {
+		if c := Hct(tt.c.Hct()); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Hct(%v.Hct()) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+	}
+}
+
+// Asking for too much chroma must keep hue and tone, and give a valid color.
+func TestHctGamutMapping(t *testing.T) {
+	for h := 0.0; h < 360.0; h += 15.0 {
+		for tone := 5.0; tone < 100.0; tone += 10.0 {
+			c := Hct(h, 200.0, tone)
+			if !c.IsValid() {
+				t.Errorf("Hct(%v, 200, %v) => (%v), which is invalid", h, tone, c)
+			}
+			hh, _, tt := c.Hct()
+			if math.Abs(tt-tone) > 0.01 {
+				t.Errorf("Hct(%v, 200, %v) has tone %v", h, tone, tt)
+			}
+			if dh := math.Abs(math.Mod(hh-h+540.0, 360.0) - 180.0); dh > 1.0 {
+				t.Errorf("Hct(%v, 200, %v) has hue %v", h, tone, hh)
+			}
+		}
+	}
+}
+
+// Ground-truth from material-color-utilities' tonal palette of pure blue.
+func TestTonalPalette(t *testing.T) {
+	tp := NewTonalPalette(Color{0.0, 0.0, 1.0})
+	want := map[float64]string{
+		100: "#ffffff",
+		95:  "#f1efff",
+		90:  "#e0e0ff",
+		80:  "#bec2ff",
+		70:  "#9da3ff",
+		60:  "#7c84ff",
+		50:  "#5a64ff",
+		40:  "#343dff",
+		30:  "#0000ef",
+		20:  "#0001ac",
+		10:  "#00006e",
+		0:   "#000000",
+	}
+	for tone, hex := range want {
+		if c := tp.Tone(tone).Hex(); c != hex {
+			t.Errorf("TonalPalette(blue).Tone(%v) => %v, want %v", tone, c, hex)
+		}
+	}
+
+	if cs := tp.Tones(0, 50, 100); len(cs) != 3 || cs[1].Hex() != want[50] {
+		t.Errorf("TonalPalette(blue).Tones(0, 50, 100) => %v", cs)
+	}
+}
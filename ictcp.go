@@ -0,0 +1,179 @@
+package colorful
+
+import "math"
+
+// This file implements the HDR transfer functions and the ICtCp color space
+// of ITU-R BT.2100, together with the ΔE ITP color difference of ITU-R BT.2124.
+
+/// Transfer functions ///
+//////////////////////////
+
+const (
+	pqM1 = 2610.0 / 16384.0
+	pqM2 = 2523.0 / 32.0
+	pqC1 = 3424.0 / 4096.0
+	pqC2 = 2413.0 / 128.0
+	pqC3 = 2392.0 / 128.0
+)
+
+// PqEncode applies the inverse EOTF of the perceptual quantizer (SMPTE ST 2084),
+// turning an absolute luminance in cd/m² (0 to 10000) into a signal in [0..1].
+func PqEncode(luminance float64) float64 {
+	yp := math.Pow(math.Max(luminance, 0)/10000.0, pqM1)
+	return math.Pow((pqC1+pqC2*yp)/(1.0+pqC3*yp), pqM2)
+}
+
+// PqDecode applies the EOTF of the perceptual quantizer (SMPTE ST 2084),
+// turning a signal in [0..1] into an absolute luminance in cd/m².
+func PqDecode(signal float64) float64 {
+	ep := math.Pow(math.Max(signal, 0), 1.0/pqM2)
+	return 10000.0 * math.Pow(math.Max(ep-pqC1, 0)/(pqC2-pqC3*ep), 1.0/pqM1)
+}
+
+const (
+	hlgA = 0.17883277
+	hlgB = 0.28466892 // 1 - 4*hlgA
+	hlgC = 0.55991073 // 0.5 - hlgA*ln(4*hlgA)
+)
+
+// HlgEncode applies the OETF of Hybrid Log-Gamma (ITU-R BT.2100), turning a
+// relative scene-linear value in [0..1] into a signal in [0..1].
+func HlgEncode(e float64) float64 {
+	if e <= 1.0/12.0 {
+		return math.Sqrt(3.0 * math.Max(e, 0))
+	}
+	return hlgA*math.Log(12.0*e-hlgB) + hlgC
+}
+
+// HlgDecode applies the inverse OETF of Hybrid Log-Gamma (ITU-R BT.2100),
+// turning a signal in [0..1] back into a relative scene-linear value in [0..1].
+func HlgDecode(signal float64) float64 {
+	if signal <= 0.5 {
+		return signal * signal / 3.0
+	}
+	return (math.Exp((signal-hlgC)/hlgA) + hlgB) / 12.0
+}
+
+/// Rec.2020 ///
+////////////////
+
+// XyzToLinearRec2020 converts from CIE XYZ-space (D65) to linear RGB with the
+// ITU-R BT.2020 primaries. Like its sRGB counterpart XyzToLinearRgb, it
+// doesn't clamp, and the scale of the input is kept.
+func XyzToLinearRec2020(x, y, z float64) (r, g, b float64) {
+	r = 1.7166511879712676*x - 0.3556707837763924*y - 0.2533662813736598*z
+	g = -0.666684351832489*x + 1.616481236634939*y + 0.01576854581391113*z
+	b = 0.017639857445310915*x - 0.042770613257808655*y + 0.942103121235474*z
+	return
+}
+
+// LinearRec2020ToXyz converts from linear RGB with the ITU-R BT.2020 primaries
+// to CIE XYZ-space (D65).
+func LinearRec2020ToXyz(r, g, b float64) (x, y, z float64) {
+	x = 0.6369580483012913*r + 0.14461690358620838*g + 0.16888097516417205*b
+	y = 0.26270021201126703*r + 0.677998071518871*g + 0.059301716469861945*b
+	z = 0.028072693049087508*g + 1.0609850577107909*b
+	return
+}
+
+/// ICtCp ///
+/////////////
+
+func linearRec2020ToIctcpLms(r, g, b float64) (l, m, s float64) {
+	l = (1688.0*r + 2146.0*g + 262.0*b) / 4096.0
+	m = (683.0*r + 2951.0*g + 462.0*b) / 4096.0
+	s = (99.0*r + 309.0*g + 3688.0*b) / 4096.0
+	return
+}
+
+func ictcpLmsToLinearRec2020(l, m, s float64) (r, g, b float64) {
+	r = 3.4366066943330784*l - 2.50645211865627*m + 0.06984542432319148*s
+	g = -0.7913295555989287*l + 1.9836004517922907*m - 0.192270896193362*s
+	b = -0.025949899690592672*l - 0.09891371471172644*m + 1.1248636144023192*s
+	return
+}
+
+func ictcpFromLms(l, m, s float64) (i, ct, cp float64) {
+	i = 0.5*l + 0.5*m
+	ct = (6610.0*l - 13613.0*m + 7003.0*s) / 4096.0
+	cp = (17933.0*l - 17390.0*m - 543.0*s) / 4096.0
+	return
+}
+
+func ictcpToLms(i, ct, cp float64) (l, m, s float64) {
+	l = i + 0.008609037037932756*ct + 0.11102962500302596*cp
+	m = i - 0.008609037037932756*ct - 0.11102962500302596*cp
+	s = i + 0.5600313357106791*ct - 0.32062717498731885*cp
+	return
+}
+
+// LinearRec2020ToICtCp converts from linear Rec.2020 RGB, given as absolute
+// values in cd/m², to ICtCp using the PQ transfer function.
+func LinearRec2020ToICtCp(r, g, b float64) (i, ct, cp float64) {
+	l, m, s := linearRec2020ToIctcpLms(r, g, b)
+	return ictcpFromLms(PqEncode(l), PqEncode(m), PqEncode(s))
+}
+
+// ICtCpToLinearRec2020 converts from PQ-encoded ICtCp to linear Rec.2020 RGB
+// in cd/m².
+func ICtCpToLinearRec2020(i, ct, cp float64) (r, g, b float64) {
+	l, m, s := ictcpToLms(i, ct, cp)
+	return ictcpLmsToLinearRec2020(PqDecode(l), PqDecode(m), PqDecode(s))
+}
+
+// LinearRec2020ToICtCpHlg converts from scene-linear Rec.2020 RGB in [0..1]
+// to ICtCp using the HLG transfer function.
+func LinearRec2020ToICtCpHlg(r, g, b float64) (i, ct, cp float64) {
+	l, m, s := linearRec2020ToIctcpLms(r, g, b)
+	return ictcpFromLms(HlgEncode(l), HlgEncode(m), HlgEncode(s))
+}
+
+// ICtCpHlgToLinearRec2020 converts from HLG-encoded ICtCp to scene-linear
+// Rec.2020 RGB in [0..1].
+func ICtCpHlgToLinearRec2020(i, ct, cp float64) (r, g, b float64) {
+	l, m, s := ictcpToLms(i, ct, cp)
+	return ictcpLmsToLinearRec2020(HlgDecode(l), HlgDecode(m), HlgDecode(s))
+}
+
+// AbsoluteXyzToICtCp converts from absolute CIE XYZ-space in cd/m² (D65) to
+// PQ-encoded ICtCp.
+func AbsoluteXyzToICtCp(x, y, z float64) (i, ct, cp float64) {
+	return LinearRec2020ToICtCp(XyzToLinearRec2020(x, y, z))
+}
+
+// ICtCpToAbsoluteXyz converts from PQ-encoded ICtCp to absolute CIE XYZ-space
+// in cd/m² (D65).
+func ICtCpToAbsoluteXyz(i, ct, cp float64) (x, y, z float64) {
+	return LinearRec2020ToXyz(ICtCpToLinearRec2020(i, ct, cp))
+}
+
+// ICtCp converts the given color to PQ-encoded ICtCp, placing sRGB white at
+// SdrWhiteLuminance.
+func (col Color) ICtCp() (i, ct, cp float64) {
+	x, y, z := col.Xyz()
+	return AbsoluteXyzToICtCp(x*SdrWhiteLuminance, y*SdrWhiteLuminance, z*SdrWhiteLuminance)
+}
+
+// ICtCp generates a color by using data given in PQ-encoded ICtCp, with sRGB
+// white at SdrWhiteLuminance.
+// WARNING: many combinations of `i`, `ct`, and `cp` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func ICtCp(i, ct, cp float64) Color {
+	x, y, z := ICtCpToAbsoluteXyz(i, ct, cp)
+	return Xyz(x/SdrWhiteLuminance, y/SdrWhiteLuminance, z/SdrWhiteLuminance)
+}
+
+// DeltaEITP computes the ΔE ITP color difference of ITU-R BT.2124 between
+// two PQ-encoded ICtCp colors. A value of 1 is about one just noticeable difference.
+func DeltaEITP(i1, ct1, cp1, i2, ct2, cp2 float64) float64 {
+	// T is half of Ct, P is Cp.
+	return 720.0 * math.Sqrt(sq(i1-i2)+sq(0.5*(ct1-ct2))+sq(cp1-cp2))
+}
+
+// DistanceITP computes the ΔE ITP color difference of ITU-R BT.2124 between
+// two colors, placing sRGB white at SdrWhiteLuminance. Use DeltaEITP for HDR colors.
+func (c1 Color) DistanceITP(c2 Color) float64 {
+	i1, ct1, cp1 := c1.ICtCp()
+	i2, ct2, cp2 := c2.ICtCp()
+	return DeltaEITP(i1, ct1, cp1, i2, ct2, cp2)
+}
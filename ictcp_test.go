@@ -0,0 +1,102 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPq(t *testing.T) {
+	tests := []struct{ luminance, signal float64 }{
+		{0.0, 7.309559025783966e-07},
+		{100.0, 0.5080784215173896},
+		{10000.0, 1.0},
+	}
+	for i, tt := range tests {
+		if s := PqEncode(tt.luminance); math.Abs(s-tt.signal) > 1e-9 {
+			t.Errorf("%v. PqEncode(%v) => %v, want %v", i, tt.luminance, s, tt.signal)
+		}
+		if l := PqDecode(tt.signal); math.Abs(l-tt.luminance) > 1e-6 {
+			t.Errorf("%v. PqDecode(%v) => %v, want %v", i, tt.signal, l, tt.luminance)
+		}
+	}
+}
+
+func TestHlg(t *testing.T) {
+	tests := []struct{ e, signal float64 }{
+		{0.0, 0.0},
+		{1.0 / 12.0, 0.5},
+		{0.5, 0.8716434642069682},
+		{1.0, 1.0},
+	}
+	for i, tt := range tests {
+		if s := HlgEncode(tt.e); math.Abs(s-tt.signal) > 1e-7 {
+			t.Errorf("%v. HlgEncode(%v) => %v, want %v", i, tt.e, s, tt.signal)
+		}
+		if e := HlgDecode(tt.signal); math.Abs(e-tt.e) > 1e-7 {
+			t.Errorf("%v. HlgDecode(%v) => %v, want %v", i, tt.signal, e, tt.e)
+		}
+	}
+}
+
+func TestRec2020(t *testing.T) {
+	// Rec.2020 white is D65, like sRGB.
+	if x, y, z := LinearRec2020ToXyz(1, 1, 1); math.Abs(x-0.95045592705) > 1e-9 || math.Abs(y-1) > 1e-9 || math.Abs(z-1.08905775076) > 1e-9 {
+		t.Errorf("LinearRec2020ToXyz(1, 1, 1) => (%v, %v, %v), want D65", x, y, z)
+	}
+	for i, tt := range vals {
+		x, y, z := tt.c.Xyz()
+		r, g, b := XyzToLinearRec2020(x, y, z)
+		if c := Xyz(LinearRec2020ToXyz(r, g, b)); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Rec.2020 roundtrip of %v => %v", i, tt.c, c)
+		}
+	}
+}
+
+// Ground-truth from colour-science.
+func TestLinearRec2020ToICtCp(t *testing.T) {
+	i, ct, cp := LinearRec2020ToICtCp(0.45620519, 0.03081071, 0.04091952)
+	want := [3]float64{0.07351364, 0.00475253, 0.09351596}
+	if math.Abs(i-want[0]) > 1e-7 || math.Abs(ct-want[1]) > 1e-7 || math.Abs(cp-want[2]) > 1e-7 {
+		t.Errorf("LinearRec2020ToICtCp => (%v, %v, %v), want %v", i, ct, cp, want)
+	}
+	if r, g, b := ICtCpToLinearRec2020(i, ct, cp); math.Abs(r-0.45620519) > 1e-8 || math.Abs(g-0.03081071) > 1e-8 || math.Abs(b-0.04091952) > 1e-8 {
+		t.Errorf("ICtCpToLinearRec2020 => (%v, %v, %v), want [0.45620519 0.03081071 0.04091952]", r, g, b)
+	}
+
+	i, ct, cp = LinearRec2020ToICtCpHlg(0.2, 0.2, 0.2)
+	if math.Abs(ct) > 1e-12 || math.Abs(cp) > 1e-12 {
+		t.Errorf("LinearRec2020ToICtCpHlg of a gray => (%v, %v, %v), want achromatic", i, ct, cp)
+	}
+	if r, g, b := ICtCpHlgToLinearRec2020(LinearRec2020ToICtCpHlg(0.7, 0.1, 0.3)); math.Abs(r-0.7) > 1e-9 || math.Abs(g-0.1) > 1e-9 || math.Abs(b-0.3) > 1e-9 {
+		t.Errorf("HLG ICtCp roundtrip => (%v, %v, %v), want [0.7 0.1 0.3]", r, g, b)
+	}
+}
+
+func TestICtCpRoundtrip(t *testing.T) {
+	for i, tt := range vals {
+		if c := ICtCp(tt.c.ICtCp()); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. ICtCp(%v.ICtCp()) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+	}
+	if i, ct, cp := (Color{1, 1, 1}).ICtCp(); math.Abs(ct) > 1e-9 || math.Abs(cp) > 1e-9 {
+		t.Errorf("White.ICtCp() => (%v, %v, %v), want achromatic", i, ct, cp)
+	}
+}
+
+func TestDistanceITP(t *testing.T) {
+	// Reference from colour-science's delta_E_ITP.
+	if d := DeltaEITP(0.4885468072, -0.04739350675, 0.07475401302, 0.4899203231, -0.04567508203, 0.07361341775); math.Abs(d-1.4265728) > 1e-6 {
+		t.Errorf("DeltaEITP => %v, want 1.4265728", d)
+	}
+
+	c1 := Color{1, 0, 0}
+	c2 := Color{0, 0, 1}
+	i1, ct1, cp1 := c1.ICtCp()
+	i2, ct2, cp2 := c2.ICtCp()
+	if d, want := c1.DistanceITP(c2), DeltaEITP(i1, ct1, cp1, i2, ct2, cp2); d != want {
+		t.Errorf("%v.DistanceITP(%v) => %v, want %v", c1, c2, d, want)
+	}
+	if d := c1.DistanceITP(c1); d != 0 {
+		t.Errorf("%v.DistanceITP(%v) => %v, want 0", c1, c1, d)
+	}
+}
@@ -0,0 +1,256 @@
+package colorful
+
+import (
+	"fmt"
+	"math"
+)
+
+/// Standard illuminants ///
+////////////////////////////
+
+// Observer selects one of the CIE standard colorimetric observers.
+type Observer int
+
+const (
+	// CIE1931 is the CIE 1931 2° standard observer, the one used throughout this library.
+	CIE1931 Observer = iota
+	// CIE1964 is the CIE 1964 10° supplementary standard observer.
+	CIE1964
+)
+
+// Illuminant holds the xy chromaticity coordinates of a standard illuminant
+// for both standard observers.
+type Illuminant struct {
+	Xy2  [2]float64 // For the CIE 1931 2° observer.
+	Xy10 [2]float64 // For the CIE 1964 10° observer, zero if not standardized.
+}
+
+// StandardIlluminants are the CIE standard illuminants, as given in CIE 15:2018.
+// The LED series is only standardized for the 2° observer.
+var StandardIlluminants = map[string]Illuminant{
+	"A":   {[2]float64{0.44757, 0.40745}, [2]float64{0.45117, 0.40594}},
+	"B":   {[2]float64{0.34842, 0.35161}, [2]float64{0.34980, 0.35270}},
+	"C":   {[2]float64{0.31006, 0.31616}, [2]float64{0.31039, 0.31905}},
+	"D50": {[2]float64{0.34567, 0.35850}, [2]float64{0.34773, 0.35952}},
+	"D55": {[2]float64{0.33242, 0.34743}, [2]float64{0.33411, 0.34877}},
+	"D65": {[2]float64{0.31271, 0.32902}, [2]float64{0.31382, 0.33100}},
+	"D75": {[2]float64{0.29902, 0.31485}, [2]float64{0.29968, 0.31740}},
+	"E":   {[2]float64{1.0 / 3.0, 1.0 / 3.0}, [2]float64{1.0 / 3.0, 1.0 / 3.0}},
+	"F1":  {[2]float64{0.31310, 0.33727}, [2]float64{0.31811, 0.33559}},
+	"F2":  {[2]float64{0.37208, 0.37529}, [2]float64{0.37925, 0.36733}},
+	"F3":  {[2]float64{0.40910, 0.39430}, [2]float64{0.41761, 0.38324}},
+	"F4":  {[2]float64{0.44018, 0.40329}, [2]float64{0.44920, 0.39074}},
+	"F5":  {[2]float64{0.31379, 0.34531}, [2]float64{0.31975, 0.34246}},
+	"F6":  {[2]float64{0.37790, 0.38835}, [2]float64{0.38660, 0.37847}},
+	"F7":  {[2]float64{0.31292, 0.32933}, [2]float64{0.31569, 0.32960}},
+	"F8":  {[2]float64{0.34588, 0.35875}, [2]float64{0.34902, 0.35939}},
+	"F9":  {[2]float64{0.37417, 0.37281}, [2]float64{0.37829, 0.37045}},
+	"F10": {[2]float64{0.34609, 0.35986}, [2]float64{0.35090, 0.35444}},
+	"F11": {[2]float64{0.38052, 0.37713}, [2]float64{0.38541, 0.37123}},
+	"F12": {[2]float64{0.43695, 0.40441}, [2]float64{0.44256, 0.39717}},
+
+	"LED-B1":   {Xy2: [2]float64{0.4560, 0.4078}},
+	"LED-B2":   {Xy2: [2]float64{0.4357, 0.4012}},
+	"LED-B3":   {Xy2: [2]float64{0.3756, 0.3723}},
+	"LED-B4":   {Xy2: [2]float64{0.3422, 0.3502}},
+	"LED-B5":   {Xy2: [2]float64{0.3118, 0.3236}},
+	"LED-BH1":  {Xy2: [2]float64{0.4474, 0.4066}},
+	"LED-RGB1": {Xy2: [2]float64{0.4557, 0.4211}},
+	"LED-V1":   {Xy2: [2]float64{0.4560, 0.4548}},
+	"LED-V2":   {Xy2: [2]float64{0.3781, 0.3775}},
+}
+
+// Xy returns the chromaticity of the illuminant for the given observer, and
+// whether it is standardized for that observer.
+func (il Illuminant) Xy(obs Observer) (x, y float64, ok bool) {
+	xy := il.Xy2
+	if obs == CIE1964 {
+		xy = il.Xy10
+	}
+	return xy[0], xy[1], xy[1] != 0
+}
+
+// WhiteRef returns the illuminant as a white reference with Y = 1, for use
+// with the *WhiteRef functions, and whether it is standardized for that observer.
+func (il Illuminant) WhiteRef(obs Observer) (wref [3]float64, ok bool) {
+	x, y, ok := il.Xy(obs)
+	if !ok {
+		return
+	}
+	wref[0], wref[1], wref[2] = XyyToXyz(x, y, 1.0)
+	return
+}
+
+// IlluminantWhiteRef looks up the standard illuminant of the given name, such
+// as "A", "D50" or "F11", and returns it as a white reference for the given observer.
+//
+// Note that these differ slightly from the D65 and D50 variables, which are
+// the values in common use for color conversions.
+func IlluminantWhiteRef(name string, obs Observer) (wref [3]float64, err error) {
+	il, ok := StandardIlluminants[name]
+	if !ok {
+		return wref, fmt.Errorf("colorful: unknown standard illuminant %q", name)
+	}
+	if wref, ok = il.WhiteRef(obs); !ok {
+		return wref, fmt.Errorf("colorful: illuminant %q is not standardized for the 10° observer", name)
+	}
+	return wref, nil
+}
+
+/// Correlated color temperature ///
+////////////////////////////////////
+// All of these work on chromaticities for the CIE 1931 2° observer, and
+// temperatures in Kelvin.
+
+// xyToUv converts xy chromaticity to the CIE 1960 UCS, in which CCT is defined.
+func xyToUv(x, y float64) (u, v float64) {
+	d := -2.0*x + 12.0*y + 3.0
+	return 4.0 * x / d, 6.0 * y / d
+}
+
+// XyToCctMcCamy estimates the correlated color temperature of the given
+// chromaticity using McCamy's cubic approximation. It is fast, and accurate
+// to a few Kelvin between 2856K and 6504K, but degrades outside of that.
+func XyToCctMcCamy(x, y float64) float64 {
+	n := (x - 0.3320) / (0.1858 - y)
+	return 449.0*n*n*n + 3525.0*n*n + 6823.3*n + 5520.33
+}
+
+// The isotemperature lines of Robertson's method: reciprocal temperature
+// in mired, CIE 1960 u and v of the locus, and slope.
+var robertsonLines = [...][4]float64{
+	{0, 0.18006, 0.26352, -0.24341},
+	{10, 0.18066, 0.26589, -0.25479},
+	{20, 0.18133, 0.26846, -0.26876},
+	{30, 0.18208, 0.27119, -0.28539},
+	{40, 0.18293, 0.27407, -0.30470},
+	{50, 0.18388, 0.27709, -0.32675},
+	{60, 0.18494, 0.28021, -0.35156},
+	{70, 0.18611, 0.28342, -0.37915},
+	{80, 0.18740, 0.28668, -0.40955},
+	{90, 0.18880, 0.28997, -0.44278},
+	{100, 0.19032, 0.29326, -0.47888},
+	{125, 0.19462, 0.30141, -0.58204},
+	{150, 0.19962, 0.30921, -0.70471},
+	{175, 0.20525, 0.31647, -0.84901},
+	{200, 0.21142, 0.32312, -1.0182},
+	{225, 0.21807, 0.32909, -1.2168},
+	{250, 0.22511, 0.33439, -1.4512},
+	{275, 0.23247, 0.33904, -1.7298},
+	{300, 0.24010, 0.34308, -2.0637},
+	{325, 0.24792, 0.34655, -2.4681},
+	{350, 0.25591, 0.34951, -2.9641},
+	{375, 0.26400, 0.35200, -3.5814},
+	{400, 0.27218, 0.35407, -4.3633},
+	{425, 0.28039, 0.35577, -5.3762},
+	{450, 0.28863, 0.35714, -6.7262},
+	{475, 0.29685, 0.35823, -8.5955},
+	{500, 0.30505, 0.35907, -11.324},
+	{525, 0.31320, 0.35968, -15.628},
+	{550, 0.32129, 0.36011, -23.325},
+	{575, 0.32931, 0.36038, -40.770},
+	{600, 0.33724, 0.36051, -116.45},
+}
+
+// XyToCctRobertson computes the correlated color temperature of the given
+// chromaticity using Robertson's method, which interpolates between
+// isotemperature lines. It works from about 1667K to infinity, and returns
+// NaN for chromaticities outside of that range.
+// See http://www.brucelindbloom.com/Eqn_XYZ_to_T.html
+func XyToCctRobertson(x, y float64) float64 {
+	u, v := xyToUv(x, y)
+
+	// Find the two adjacent isotemperature lines between which the color lies.
+	var dm, di float64
+	i := 0
+	for ; i < len(robertsonLines); i++ {
+		l := robertsonLines[i]
+		di = (v - l[2]) - l[3]*(u-l[1])
+		if i > 0 && (di < 0.0) != (dm < 0.0) {
+			break
+		}
+		dm = di
+	}
+	if i == 0 || i == len(robertsonLines) {
+		return math.NaN()
+	}
+
+	// Interpolate by the normal distances to both lines.
+	di /= math.Sqrt(1.0 + sq(robertsonLines[i][3]))
+	dm /= math.Sqrt(1.0 + sq(robertsonLines[i-1][3]))
+	p := dm / (dm - di)
+	return 1e6 / (robertsonLines[i-1][0] + p*(robertsonLines[i][0]-robertsonLines[i-1][0]))
+}
+
+// planckianUv returns the CIE 1960 u and v of a blackbody radiator, using
+// Krystek's rational approximation, which is valid from 1000K to 15000K.
+func planckianUv(cct float64) (u, v float64) {
+	t2 := cct * cct
+	u = (0.860117757 + 1.54118254e-4*cct + 1.28641212e-7*t2) / (1.0 + 8.42420235e-4*cct + 7.08145163e-7*t2)
+	v = (0.317398726 + 4.22806245e-5*cct + 4.20481691e-8*t2) / (1.0 - 2.89741816e-5*cct + 1.61456053e-7*t2)
+	return
+}
+
+// XyToDuv computes Duv, the signed distance in the CIE 1960 UCS of the given
+// chromaticity to the Planckian locus, at its correlated color temperature.
+// It is positive above the locus (greenish) and negative below (pinkish).
+// Valid for correlated color temperatures from 1667K to 15000K.
+func XyToDuv(x, y float64) float64 {
+	u, v := xyToUv(x, y)
+	up, vp := planckianUv(XyToCctRobertson(x, y))
+	duv := math.Sqrt(sq(u-up) + sq(v-vp))
+	if v < vp {
+		return -duv
+	}
+	return duv
+}
+
+// CctToXyPlanckian returns the chromaticity of a blackbody radiator of the
+// given temperature, using the cubic spline approximation of Kim et al.,
+// which is valid from 1667K to 25000K.
+func CctToXyPlanckian(cct float64) (x, y float64) {
+	t := 1e3 / cct
+	if cct <= 4000.0 {
+		x = ((-0.2661239*t-0.2343589)*t+0.8776956)*t + 0.179910
+	} else {
+		x = ((-3.0258469*t+2.1070379)*t+0.2226347)*t + 0.240390
+	}
+
+	if cct <= 2222.0 {
+		y = ((-1.1063814*x-1.34811020)*x+2.18555832)*x - 0.20219683
+	} else if cct <= 4000.0 {
+		y = ((-0.9549476*x-1.37418593)*x+2.09137015)*x - 0.16748867
+	} else {
+		y = ((3.0817580*x-5.87338670)*x+3.75112997)*x - 0.37001483
+	}
+	return
+}
+
+// CctToXyDaylight returns the chromaticity of CIE daylight of the given
+// correlated color temperature, valid from 4000K to 25000K.
+// Note that D65 is at 6504K, not 6500K, due to a revision of Planck's constant.
+func CctToXyDaylight(cct float64) (x, y float64) {
+	t := 1e3 / cct
+	if cct <= 7000.0 {
+		x = ((-4.6070*t+2.9678)*t+0.09911)*t + 0.244063
+	} else {
+		x = ((-2.0064*t+1.9018)*t+0.24748)*t + 0.237040
+	}
+	y = -3.0*x*x + 2.870*x - 0.275
+	return
+}
+
+// WhiteRefFromKelvin returns a white reference with Y = 1 for the given
+// color temperature, as a photographer's white balance setting would.
+// From 4000K on it lies on the daylight locus, below that on the Planckian
+// locus, so there is a small jump in tint at 4000K.
+func WhiteRefFromKelvin(cct float64) (wref [3]float64) {
+	var x, y float64
+	if cct < 4000.0 {
+		x, y = CctToXyPlanckian(cct)
+	} else {
+		x, y = CctToXyDaylight(cct)
+	}
+	wref[0], wref[1], wref[2] = XyyToXyz(x, y, 1.0)
+	return
+}
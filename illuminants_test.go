@@ -0,0 +1,115 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIlluminantWhiteRef(t *testing.T) {
+	tests := []struct {
+		name string
+		obs  Observer
+		want [3]float64
+	}{
+		{"A", CIE1931, [3]float64{1.09850, 1.0, 0.35585}},
+		{"D65", CIE1931, [3]float64{0.95047, 1.0, 1.08883}},
+		{"D65", CIE1964, [3]float64{0.94811, 1.0, 1.07304}},
+		{"D50", CIE1931, [3]float64{0.96422, 1.0, 0.82521}},
+		{"E", CIE1964, [3]float64{1.0, 1.0, 1.0}},
+	}
+	for i, tt := range tests {
+		wref, err := IlluminantWhiteRef(tt.name, tt.obs)
+		if err != nil {
+			t.Errorf("%v. IlluminantWhiteRef(%v, %v) => error %v", i, tt.name, tt.obs, err)
+		}
+		if math.Abs(wref[0]-tt.want[0]) > 1e-3 || wref[1] != 1.0 || math.Abs(wref[2]-tt.want[2]) > 1e-3 {
+			t.Errorf("%v. IlluminantWhiteRef(%v, %v) => %v, want %v", i, tt.name, tt.obs, wref, tt.want)
+		}
+	}
+
+	if _, err := IlluminantWhiteRef("D66", CIE1931); err == nil {
+		t.Errorf("IlluminantWhiteRef(D66) => no error, want unknown illuminant")
+	}
+	if _, err := IlluminantWhiteRef("LED-B1", CIE1964); err == nil {
+		t.Errorf("IlluminantWhiteRef(LED-B1, CIE1964) => no error, want not standardized")
+	}
+	if _, err := IlluminantWhiteRef("LED-B1", CIE1931); err != nil {
+		t.Errorf("IlluminantWhiteRef(LED-B1, CIE1931) => error %v", err)
+	}
+}
+
+// Ground-truth from CIE 15:2018 and http://www.brucelindbloom.com/Eqn_XYZ_to_T.html
+var ccts = []struct {
+	name string
+	cct  float64
+	duv  float64
+}{
+	{"A", 2856, 0.0},
+	{"D50", 5003, 0.0033},
+	{"D65", 6504, 0.0032},
+	{"D75", 7504, 0.0030},
+}
+
+func TestXyToCct(t *testing.T) {
+	for i, tt := range ccts {
+		x, y, _ := StandardIlluminants[tt.name].Xy(CIE1931)
+		if cct := XyToCctMcCamy(x, y); math.Abs(cct-tt.cct) > 5 {
+			t.Errorf("%v. XyToCctMcCamy(%v) => %v, want %v", i, tt.name, cct, tt.cct)
+		}
+		if cct := XyToCctRobertson(x, y); math.Abs(cct-tt.cct) > 5 {
+			t.Errorf("%v. XyToCctRobertson(%v) => %v, want %v", i, tt.name, cct, tt.cct)
+		}
+		if duv := XyToDuv(x, y); math.Abs(duv-tt.duv) > 2e-4 {
+			t.Errorf("%v. XyToDuv(%v) => %v, want %v", i, tt.name, duv, tt.duv)
+		}
+	}
+
+	// Far below the Planckian locus' range.
+	if cct := XyToCctRobertson(0.7, 0.29); !math.IsNaN(cct) {
+		t.Errorf("XyToCctRobertson(0.7, 0.29) => %v, want NaN", cct)
+	}
+}
+
+func TestCctToXy(t *testing.T) {
+	for _, cct := range []float64{1700, 2000, 2856, 3500, 4000, 5000, 6504, 10000, 15000} {
+		x, y := CctToXyPlanckian(cct)
+		if c := XyToCctRobertson(x, y); math.Abs(c-cct)/cct > 0.003 {
+			t.Errorf("XyToCctRobertson(CctToXyPlanckian(%v)) => %v", cct, c)
+		}
+		if duv := XyToDuv(x, y); math.Abs(duv) > 5e-4 {
+			t.Errorf("XyToDuv(CctToXyPlanckian(%v)) => %v, want 0", cct, duv)
+		}
+	}
+
+	// D65 and D50 are on the daylight locus.
+	tests := []struct {
+		name string
+		cct  float64
+	}{
+		{"D50", 5003}, {"D65", 6504}, {"D75", 7504},
+	}
+	for i, tt := range tests {
+		want, _, _ := StandardIlluminants[tt.name].Xy(CIE1931)
+		if x, y := CctToXyDaylight(tt.cct); math.Abs(x-want) > 2e-4 {
+			t.Errorf("%v. CctToXyDaylight(%v) => (%v, %v), want %v", i, tt.cct, x, y, StandardIlluminants[tt.name].Xy2)
+		}
+	}
+}
+
+func TestWhiteRefFromKelvin(t *testing.T) {
+	if wref := WhiteRefFromKelvin(6504); math.Abs(wref[0]-D65[0]) > 1e-3 || wref[1] != 1.0 || math.Abs(wref[2]-D65[2]) > 1e-3 {
+		t.Errorf("WhiteRefFromKelvin(6504) => %v, want %v", wref, D65)
+	}
+
+	// Lower temperatures are warmer, i.e. less blue, on both loci.
+	for _, ccts := range [][]float64{{1700, 2000, 3000, 3999}, {4000, 5000, 8000, 20000}} {
+		prev := WhiteRefFromKelvin(ccts[0])
+		for _, cct := range ccts[1:] {
+			wref := WhiteRefFromKelvin(cct)
+			if wref[2] <= prev[2] {
+				t.Errorf("WhiteRefFromKelvin(%v) => %v is not bluer than the previous %v", cct, wref, prev)
+			}
+			prev = wref
+		}
+	}
+}
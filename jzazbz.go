@@ -0,0 +1,155 @@
+package colorful
+
+import "math"
+
+// Jzazbz is a perceptually uniform color space for high dynamic range (HDR)
+// and wide gamut content. Unlike everything else in this library, it works on
+// absolute CIE XYZ values in cd/m² (D65), so that colors brighter than diffuse
+// white can be represented.
+//
+// Source: Safdar et al., "Perceptually uniform color space for image signals
+// including high dynamic range and wide gamut", Optics Express 25(13), 2017.
+//
+// Jz is in about [0..0.2] for SDR content, and [0..1] for up to 10000 cd/m².
+
+// SdrWhiteLuminance is the luminance in cd/m² at which sRGB white is placed
+// by the Color methods of the HDR color spaces, following ITU-R BT.2408.
+const SdrWhiteLuminance = 203.0
+
+const (
+	jzB  = 1.15
+	jzG  = 0.66
+	jzC1 = 3424.0 / 4096.0
+	jzC2 = 2413.0 / 128.0
+	jzC3 = 2392.0 / 128.0
+	jzN  = 2610.0 / 16384.0
+	jzP  = 1.7 * 2523.0 / 32.0
+	jzD  = -0.56
+	jzD0 = 1.6295499532821566e-11
+)
+
+// The perceptual quantizer curve, with Jzazbz's modified exponent.
+func jzPq(v float64) float64 {
+	vn := math.Pow(math.Max(v, 0)/10000.0, jzN)
+	return math.Pow((jzC1+jzC2*vn)/(1.0+jzC3*vn), jzP)
+}
+
+func jzPqInv(v float64) float64 {
+	vp := math.Pow(v, 1.0/jzP)
+	return 10000.0 * math.Pow(math.Max(0, (jzC1-vp)/(jzC3*vp-jzC2)), 1.0/jzN)
+}
+
+// AbsoluteXyzToJzazbz converts from absolute CIE XYZ-space in cd/m² (D65) to Jzazbz.
+func AbsoluteXyzToJzazbz(x, y, z float64) (jz, az, bz float64) {
+	xp := jzB*x - (jzB-1.0)*z
+	yp := jzG*y - (jzG-1.0)*x
+
+	l := jzPq(0.41478972*xp + 0.579999*yp + 0.0146480*z)
+	m := jzPq(-0.2015100*xp + 1.120649*yp + 0.0531008*z)
+	s := jzPq(-0.0166008*xp + 0.264800*yp + 0.6684799*z)
+
+	iz := 0.5*l + 0.5*m
+	az = 3.524000*l - 4.066708*m + 0.542708*s
+	bz = 0.199076*l + 1.096799*m - 1.295875*s
+	jz = (1.0+jzD)*iz/(1.0+jzD*iz) - jzD0
+	return
+}
+
+// JzazbzToAbsoluteXyz converts from Jzazbz to absolute CIE XYZ-space in cd/m² (D65).
+func JzazbzToAbsoluteXyz(jz, az, bz float64) (x, y, z float64) {
+	jz += jzD0
+	iz := jz / (1.0 + jzD - jzD*jz)
+
+	l := jzPqInv(iz + 0.1386050432715393*az + 0.05804731615611886*bz)
+	m := jzPqInv(iz - 0.1386050432715393*az - 0.05804731615611886*bz)
+	s := jzPqInv(iz - 0.09601924202631894*az - 0.8118918960560388*bz)
+
+	xp := 1.924226435787607*l - 1.004792312595366*m + 0.03765140403061801*s
+	yp := 0.3503167620949992*l + 0.7264811939316554*m - 0.06538442294808504*s
+	z = -0.09098281098284759*l - 0.312728290523074*m + 1.522766561305261*s
+
+	x = (xp + (jzB-1.0)*z) / jzB
+	y = (yp + (jzG-1.0)*x) / jzG
+	return
+}
+
+// Jzazbz converts the given color to Jzazbz, placing sRGB white at SdrWhiteLuminance.
+func (col Color) Jzazbz() (jz, az, bz float64) {
+	return col.JzazbzWhiteLuminance(SdrWhiteLuminance)
+}
+
+// JzazbzWhiteLuminance converts the given color to Jzazbz, placing sRGB
+// white at the given luminance in cd/m².
+func (col Color) JzazbzWhiteLuminance(lw float64) (jz, az, bz float64) {
+	x, y, z := col.Xyz()
+	return AbsoluteXyzToJzazbz(x*lw, y*lw, z*lw)
+}
+
+// Jzazbz generates a color by using data given in Jzazbz, with sRGB white
+// at SdrWhiteLuminance.
+// WARNING: many combinations of `jz`, `az`, and `bz` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func Jzazbz(jz, az, bz float64) Color {
+	return JzazbzWhiteLuminance(jz, az, bz, SdrWhiteLuminance)
+}
+
+// JzazbzWhiteLuminance generates a color by using data given in Jzazbz, with
+// sRGB white at the given luminance in cd/m². Colors brighter than that
+// white will not be valid.
+func JzazbzWhiteLuminance(jz, az, bz, lw float64) Color {
+	x, y, z := JzazbzToAbsoluteXyz(jz, az, bz)
+	return Xyz(x/lw, y/lw, z/lw)
+}
+
+/// JzCzhz ///
+//////////////
+// JzCzhz is nothing else than Jzazbz in cylindrical coordinates.
+
+// JzazbzToJzCzhz converts from Jzazbz to its cylindrical JzCzhz representation.
+func JzazbzToJzCzhz(jz, az, bz float64) (j, cz, hz float64) {
+	cz = math.Sqrt(sq(az) + sq(bz))
+	// The hue of grays is only floating point noise, so it's set to 0. Jzazbz
+	// values are smaller than OkLab ones, hence the smaller threshold.
+	if cz > 1e-6 {
+		hz = math.Mod(57.29577951308232087721*math.Atan2(bz, az)+360.0, 360.0) // Rad2Deg
+	}
+	j = jz
+	return
+}
+
+// JzCzhzToJzazbz converts from cylindrical JzCzhz back to Jzazbz.
+func JzCzhzToJzazbz(jz, cz, hz float64) (j, az, bz float64) {
+	H := 0.01745329251994329576 * hz // Deg2Rad
+	az = cz * math.Cos(H)
+	bz = cz * math.Sin(H)
+	j = jz
+	return
+}
+
+// JzCzhz converts the given color to JzCzhz, placing sRGB white at SdrWhiteLuminance.
+// hz is in [0..360].
+func (col Color) JzCzhz() (jz, cz, hz float64) {
+	return JzazbzToJzCzhz(col.Jzazbz())
+}
+
+// JzCzhz generates a color by using data given in JzCzhz, with sRGB white
+// at SdrWhiteLuminance.
+// WARNING: many combinations of `jz`, `cz`, and `hz` values do not have corresponding
+// valid RGB values, check the FAQ in the README if you're unsure.
+func JzCzhz(jz, cz, hz float64) Color {
+	return Jzazbz(JzCzhzToJzazbz(jz, cz, hz))
+}
+
+// DeltaEz computes the ΔEz color difference between two Jzazbz colors.
+// It is defined on JzCzhz, but works out to be the Euclidean distance in Jzazbz.
+func DeltaEz(jz1, az1, bz1, jz2, az2, bz2 float64) float64 {
+	return math.Sqrt(sq(jz1-jz2) + sq(az1-az2) + sq(bz1-bz2))
+}
+
+// DistanceJz computes the ΔEz color difference between two colors, placing
+// sRGB white at SdrWhiteLuminance. Use DeltaEz for HDR colors.
+func (c1 Color) DistanceJz(c2 Color) float64 {
+	jz1, az1, bz1 := c1.Jzazbz()
+	jz2, az2, bz2 := c2.Jzazbz()
+	return DeltaEz(jz1, az1, bz1, jz2, az2, bz2)
+}
@@ -0,0 +1,89 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+// Ground-truth from colour-science, for absolute XYZ in cd/m².
+func TestAbsoluteXyzToJzazbz(t *testing.T) {
+	jz, az, bz := AbsoluteXyzToJzazbz(0.20654008, 0.12197225, 0.05136952)
+	want := [3]float64{0.0053504, 0.0092430, 0.0052600}
+	if math.Abs(jz-want[0]) > 1e-7 || math.Abs(az-want[1]) > 1e-7 || math.Abs(bz-want[2]) > 1e-7 {
+		t.Errorf("AbsoluteXyzToJzazbz => (%v, %v, %v), want %v", jz, az, bz, want)
+	}
+
+	x, y, z := JzazbzToAbsoluteXyz(jz, az, bz)
+	if math.Abs(x-0.20654008) > 1e-9 || math.Abs(y-0.12197225) > 1e-9 || math.Abs(z-0.05136952) > 1e-9 {
+		t.Errorf("JzazbzToAbsoluteXyz => (%v, %v, %v), want [0.20654008 0.12197225 0.05136952]", x, y, z)
+	}
+}
+
+func TestJzazbzRoundtrip(t *testing.T) {
+	for i, tt := range vals {
+		if c := Jzazbz(tt.c.Jzazbz()); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. Jzazbz(%v.Jzazbz()) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+		if c := JzCzhz(tt.c.JzCzhz()); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. JzCzhz(%v.JzCzhz()) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+		jz, az, bz := tt.c.JzazbzWhiteLuminance(1000)
+		if c := JzazbzWhiteLuminance(jz, az, bz, 1000); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. JzazbzWhiteLuminance(%v.JzazbzWhiteLuminance(1000)) => (%v), want %v (delta %v)", i, tt.c, c, tt.c, delta)
+		}
+	}
+}
+
+func TestJzazbzToJzCzhzHue(t *testing.T) {
+	tests := []struct {
+		az, bz float64
+		hz     float64
+	}{
+		{0.0, 0.01, 90.0},
+		{0.0, -0.01, 270.0},
+		{0.01, 0.01, 45.0},
+		{-0.01, 0.0, 180.0},
+		{0.0000001, 0.0000002, 0.0}, // Gray
+	}
+	for i, tt := range tests {
+		if _, _, hz := JzazbzToJzCzhz(0.1, tt.az, tt.bz); !almosteq(hz, tt.hz) {
+			t.Errorf("%v. JzazbzToJzCzhz(0.1, %v, %v) => hue %v, want %v", i, tt.az, tt.bz, hz, tt.hz)
+		}
+	}
+}
+
+func TestJzazbzWhiteLuminance(t *testing.T) {
+	// A brighter white must have a higher Jz, and 10000 cd/m² is the top of the scale.
+	jz203, _, _ := Color{1, 1, 1}.Jzazbz()
+	jz1000, _, _ := Color{1, 1, 1}.JzazbzWhiteLuminance(1000)
+	jz10000, _, _ := Color{1, 1, 1}.JzazbzWhiteLuminance(10000)
+	if !(jz203 < jz1000 && jz1000 < jz10000) {
+		t.Errorf("Jz of white is not increasing with luminance: %v, %v, %v", jz203, jz1000, jz10000)
+	}
+	if math.Abs(jz10000-1.0) > 0.02 {
+		t.Errorf("Jz of 10000 cd/m² white => %v, want about 1", jz10000)
+	}
+}
+
+func TestDistanceJz(t *testing.T) {
+	c1 := Color{1, 0, 0}
+	c2 := Color{0, 0, 1}
+	jz1, az1, bz1 := c1.Jzazbz()
+	jz2, cz2, hz2 := c2.JzCzhz()
+	_, cz1, hz1 := c1.JzCzhz()
+
+	// ΔEz as defined on JzCzhz in the paper.
+	dh := 2.0 * math.Sqrt(cz1*cz2) * math.Sin((hz2-hz1)*math.Pi/360.0)
+	want := math.Sqrt(sq(jz2-jz1) + sq(cz2-cz1) + sq(dh))
+
+	if d := c1.DistanceJz(c2); math.Abs(d-want) > 1e-12 {
+		t.Errorf("%v.DistanceJz(%v) => %v, want %v", c1, c2, d, want)
+	}
+	if d := c1.DistanceJz(c1); d != 0 {
+		t.Errorf("%v.DistanceJz(%v) => %v, want 0", c1, c1, d)
+	}
+	jz2, az2, bz2 := c2.Jzazbz()
+	if d := DeltaEz(jz1, az1, bz1, jz2, az2, bz2); d != c1.DistanceJz(c2) {
+		t.Errorf("DeltaEz => %v, want %v", d, c1.DistanceJz(c2))
+	}
+}
@@ -0,0 +1,404 @@
+package colorful
+
+import "math"
+
+// Source: https://bottosson.github.io/posts/colorpicker/
+// Under MIT License, Copyright (c) 2021 Björn Ottosson
+// Modified so that Hue is in [0..360] instead of [0..1], and so that it works
+// with this library in general.
+
+// Okhsv and Okhsl are computed directly from linear sRGB using the original
+// matrices, since the gamut cusp approximations below were fitted to them.
+func linearRgbToOkLab(r, g, b float64) (L, A, B float64) {
+	l_ := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m_ := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s_ := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	L = 0.2104542553*l_ + 0.7936177850*m_ - 0.0040720468*s_
+	A = 1.9779984951*l_ - 2.4285922050*m_ + 0.4505937099*s_
+	B = 0.0259040371*l_ + 0.7827717662*m_ - 0.8086757660*s_
+	return
+}
+
+func okLabToLinearRgb(L, A, B float64) (r, g, b float64) {
+	l := cub(L + 0.3963377774*A + 0.2158037573*B)
+	m := cub(L - 0.1055613458*A - 0.0638541728*B)
+	s := cub(L - 0.0894841775*A - 1.2914855480*B)
+	r = 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g = -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b = -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+	return
+}
+
+// Finds the maximum saturation possible for a given hue that fits in sRGB.
+// Saturation here is defined as S = C/L. a and b must be normalized so
+// a^2 + b^2 == 1.
+func okMaxSaturation(a, b float64) float64 {
+	// Max saturation will be when one of r, g or b goes below zero.
+	// Select different coefficients depending on which component goes below zero first.
+	var k0, k1, k2, k3, k4, wl, wm, ws float64
+	if -1.88170328*a-0.80936493*b > 1 {
+		// Red component
+		k0, k1, k2, k3, k4 = 1.19086277, 1.76576728, 0.59662641, 0.75515197, 0.56771245
+		wl, wm, ws = 4.0767416621, -3.3077115913, 0.2309699292
+	} else if 1.81444104*a-1.19445276*b > 1 {
+		// Green component
+		k0, k1, k2, k3, k4 = 0.73956515, -0.45954404, 0.08285427, 0.12541070, 0.14503204
+		wl, wm, ws = -1.2684380046, 2.6097574011, -0.3413193965
+	} else {
+		// Blue component
+		k0, k1, k2, k3, k4 = 1.35733652, -0.00915799, -1.15130210, -0.50559606, 0.00692167
+		wl, wm, ws = -0.0041960863, -0.7034186147, 1.7076147010
+	}
+
+	// Approximate max saturation using a polynomial.
+	S := k0 + k1*a + k2*b + k3*a*a + k4*a*b
+
+	// Do one step Halley's method to get closer.
+	kl := 0.3963377774*a + 0.2158037573*b
+	km := -0.1055613458*a - 0.0638541728*b
+	ks := -0.0894841775*a - 1.2914855480*b
+
+	l_ := 1.0 + S*kl
+	m_ := 1.0 + S*km
+	s_ := 1.0 + S*ks
+
+	l := l_ * l_ * l_
+	m := m_ * m_ * m_
+	s := s_ * s_ * s_
+
+	ldS := 3.0 * kl * l_ * l_
+	mdS := 3.0 * km * m_ * m_
+	sdS := 3.0 * ks * s_ * s_
+
+	ldS2 := 6.0 * kl * kl * l_
+	mdS2 := 6.0 * km * km * m_
+	sdS2 := 6.0 * ks * ks * s_
+
+	f := wl*l + wm*m + ws*s
+	f1 := wl*ldS + wm*mdS + ws*sdS
+	f2 := wl*ldS2 + wm*mdS2 + ws*sdS2
+
+	return S - f*f1/(f1*f1-0.5*f*f2)
+}
+
+// Finds the L and C of the cusp of the sRGB gamut for a given hue.
+// a and b must be normalized so a^2 + b^2 == 1.
+func okFindCusp(a, b float64) (lcusp, ccusp float64) {
+	// First, find the maximum saturation (saturation S = C/L).
+	scusp := okMaxSaturation(a, b)
+
+	// Convert to linear sRGB to find the first point where at least one of r, g or b >= 1.
+	r, g, bl := okLabToLinearRgb(1, scusp*a, scusp*b)
+	lcusp = math.Cbrt(1.0 / math.Max(math.Max(r, g), bl))
+	ccusp = lcusp * scusp
+	return
+}
+
+// Finds the intersection of the line defined by
+// L = L0 * (1 - t) + t * L1;
+// C = t * C1;
+// with the sRGB gamut. a and b must be normalized so a^2 + b^2 == 1.
+func okFindGamutIntersection(a, b, L1, C1, L0, lcusp, ccusp float64) float64 {
+	if (L1-L0)*ccusp-(lcusp-L0)*C1 <= 0 {
+		// Lower half
+		return ccusp * L0 / (C1*lcusp + ccusp*(L0-L1))
+	}
+
+	// Upper half
+
+	// First intersect with triangle.
+	t := ccusp * (L0 - 1.0) / (C1*(lcusp-1.0) + ccusp*(L0-L1))
+
+	// Then one step Halley's method.
+	dL := L1 - L0
+	dC := C1
+
+	kl := 0.3963377774*a + 0.2158037573*b
+	km := -0.1055613458*a - 0.0638541728*b
+	ks := -0.0894841775*a - 1.2914855480*b
+
+	ldt := dL + dC*kl
+	mdt := dL + dC*km
+	sdt := dL + dC*ks
+
+	// If higher accuracy is required, 2 or 3 iterations of the following block can be used.
+	L := L0*(1.0-t) + t*L1
+	C := t * C1
+
+	l_ := L + C*kl
+	m_ := L + C*km
+	s_ := L + C*ks
+
+	l := l_ * l_ * l_
+	m := m_ * m_ * m_
+	s := s_ * s_ * s_
+
+	ldt1 := 3 * ldt * l_ * l_
+	mdt1 := 3 * mdt * m_ * m_
+	sdt1 := 3 * sdt * s_ * s_
+
+	ldt2 := 6 * ldt * ldt * l_
+	mdt2 := 6 * mdt * mdt * m_
+	sdt2 := 6 * sdt * sdt * s_
+
+	halley := func(wl, wm, ws float64) float64 {
+		v := wl*l + wm*m + ws*s - 1
+		v1 := wl*ldt1 + wm*mdt1 + ws*sdt1
+		v2 := wl*ldt2 + wm*mdt2 + ws*sdt2
+		u := v1 / (v1*v1 - 0.5*v*v2)
+		if u < 0 {
+			return math.MaxFloat64
+		}
+		return -v * u
+	}
+	tr := halley(4.0767416621, -3.3077115913, 0.2309699292)
+	tg := halley(-1.2684380046, 2.6097574011, -0.3413193965)
+	tb := halley(-0.0041960863, -0.7034186147, 1.7076147010)
+
+	return t + math.Min(tr, math.Min(tg, tb))
+}
+
+// The toe is a lightness estimate that better matches CIE L* than OkLab's L,
+// while keeping OkLab's nice properties for the rest of the range.
+const (
+	okToeK1 = 0.206
+	okToeK2 = 0.03
+	okToeK3 = (1.0 + okToeK1) / (1.0 + okToeK2)
+)
+
+func okToe(x float64) float64 {
+	return 0.5 * (okToeK3*x - okToeK1 + math.Sqrt(sq(okToeK3*x-okToeK1)+4*okToeK2*okToeK3*x))
+}
+
+func okToeInv(x float64) float64 {
+	return (x*x + okToeK1*x) / (okToeK3 * (x + okToeK2))
+}
+
+// Returns a smooth approximation of the location of the cusp, as S = C/L
+// and T = C/(1-L). This polynomial was created by fitting to the real cusp.
+func okSTMid(a, b float64) (S, T float64) {
+	S = 0.11516993 + 1.0/(7.44778970+4.15901240*b+
+		a*(-2.19557347+1.75198401*b+
+			a*(-2.13704948-10.02301043*b+
+				a*(-4.24894561+5.38770819*b+4.69891013*a))))
+
+	T = 0.11239642 + 1.0/(1.61320320-0.68124379*b+
+		a*(0.40370612+0.90148123*b+
+			a*(-0.27087943+0.61223990*b+
+				a*(0.00299215-0.45399568*b-0.14661872*a))))
+	return
+}
+
+// Computes the three chroma values Okhsl interpolates between for a given
+// lightness and hue: C0 at s = 0, Cmid at s = 0.8 and Cmax at s = 1.
+func okCs(L, a, b float64) (C0, Cmid, Cmax float64) {
+	lcusp, ccusp := okFindCusp(a, b)
+
+	Cmax = okFindGamutIntersection(a, b, L, 1, L, lcusp, ccusp)
+	Smax, Tmax := ccusp/lcusp, ccusp/(1-lcusp)
+
+	// Scale factor to compensate for the curved part of gamut shape.
+	k := Cmax / math.Min(L*Smax, (1-L)*Tmax)
+
+	Smid, Tmid := okSTMid(a, b)
+
+	// Use a soft minimum function, instead of a sharp triangle shape to get a smooth value for chroma.
+	Ca := L * Smid
+	Cb := (1.0 - L) * Tmid
+	Cmid = 0.9 * k * math.Sqrt(math.Sqrt(1.0/(1.0/(Ca*Ca*Ca*Ca)+1.0/(Cb*Cb*Cb*Cb))))
+
+	// For C0, the shape is independent of hue, so S and T are constant.
+	// Values picked to roughly be the average values of S and T.
+	Ca = L * 0.4
+	Cb = (1.0 - L) * 0.8
+	C0 = math.Sqrt(1.0 / (1.0/(Ca*Ca) + 1.0/(Cb*Cb)))
+	return
+}
+
+// Returns the normalized hue direction and Okhsl/Okhsv hue in [0..360] of an
+// OkLab color. For achromatic colors, the hue is 0.
+func okHue(A, B float64) (a, b, C, h float64) {
+	C = math.Sqrt(A*A + B*B)
+	if C < 1e-6 {
+		return 1, 0, 0, 0
+	}
+	return A / C, B / C, C, 180.0 + 57.29577951308232087721*math.Atan2(-B, -A)
+}
+
+/// Okhsl ///
+/////////////
+
+// Okhsl returns the Hue [0..360], Saturation [0..1] and Lightness [0..1] of
+// the color in the Okhsl color space.
+func (col Color) Okhsl() (h, s, l float64) {
+	return linearRgbToOkhsl(col.LinearRgb())
+}
+
+// linearRgbToOkhsl converts linear RGB to Okhsl, see Color.Okhsl.
+func linearRgbToOkhsl(lr, lg, lb float64) (h, s, l float64) {
+	L, A, B := linearRgbToOkLab(lr, lg, lb)
+	if L >= 1.0-1e-7 {
+		return 0, 0, 1
+	} else if L <= 1e-7 {
+		return 0, 0, 0
+	}
+
+	a, b, C, h := okHue(A, B)
+	if C == 0 {
+		return h, 0, okToe(L)
+	}
+
+	C0, Cmid, Cmax := okCs(L, a, b)
+
+	// Inverse of the interpolation in Okhsl.
+	const mid = 0.8
+	const midInv = 1.25
+	if C < Cmid {
+		k1 := mid * C0
+		k2 := 1.0 - k1/Cmid
+
+		t := C / (k1 + k2*C)
+		s = t * mid
+	} else {
+		k0 := Cmid
+		k1 := (1.0 - mid) * Cmid * Cmid * midInv * midInv / C0
+		k2 := 1.0 - k1/(Cmax-Cmid)
+
+		t := (C - k0) / (k1 + k2*(C-k0))
+		s = mid + (1.0-mid)*t
+	}
+
+	return h, s, okToe(L)
+}
+
+// Okhsl creates a new Color given a Hue in [0..360], a Saturation [0..1],
+// and a Lightness in [0..1] in the Okhsl color space.
+func Okhsl(h, s, l float64) Color {
+	if l >= 1.0 {
+		return Color{1, 1, 1}
+	} else if l <= 0.0 {
+		return Color{0, 0, 0}
+	}
+
+	H := 0.01745329251994329576 * h // Deg2Rad
+	a := math.Cos(H)
+	b := math.Sin(H)
+	L := okToeInv(l)
+
+	C0, Cmid, Cmax := okCs(L, a, b)
+
+	// Interpolate the three chroma values, so that
+	// s = 0 -> C = 0, s = 0.8 -> C = Cmid, s = 1 -> C = Cmax
+	const mid = 0.8
+	const midInv = 1.25
+	var C float64
+	if s < mid {
+		t := midInv * s
+
+		k1 := mid * C0
+		k2 := 1.0 - k1/Cmid
+
+		C = t * k1 / (1.0 - k2*t)
+	} else {
+		t := (s - mid) / (1 - mid)
+
+		k0 := Cmid
+		k1 := (1.0 - mid) * Cmid * Cmid * midInv * midInv / C0
+		k2 := 1.0 - k1/(Cmax-Cmid)
+
+		C = k0 + t*k1/(1.0-k2*t)
+	}
+
+	return LinearRgb(okLabToLinearRgb(L, C*a, C*b))
+}
+
+/// Okhsv ///
+/////////////
+
+// Okhsv returns the Hue [0..360], Saturation [0..1] and Value [0..1] of
+// the color in the Okhsv color space.
+func (col Color) Okhsv() (h, s, v float64) {
+	return linearRgbToOkhsv(col.LinearRgb())
+}
+
+// linearRgbToOkhsv converts linear RGB to Okhsv, see Color.Okhsv.
+func linearRgbToOkhsv(lr, lg, lb float64) (h, s, v float64) {
+	L, A, B := linearRgbToOkLab(lr, lg, lb)
+	if L >= 1.0-1e-7 {
+		return 0, 0, 1
+	} else if L <= 1e-7 {
+		return 0, 0, 0
+	}
+
+	a, b, C, h := okHue(A, B)
+
+	lcusp, ccusp := okFindCusp(a, b)
+	Smax, Tmax := ccusp/lcusp, ccusp/(1-lcusp)
+	const S0 = 0.5
+	k := 1 - S0/Smax
+
+	// First we find Lv, Cv, Lvt and Cvt.
+	t := Tmax / (C + L*Tmax)
+	Lv := t * L
+	Cv := t * C
+
+	Lvt := okToeInv(Lv)
+	Cvt := Cv * Lvt / Lv
+
+	// We can then use these to invert the step that compensates for the toe
+	// and the curved top part of the triangle.
+	r, g, bl := okLabToLinearRgb(Lvt, a*Cvt, b*Cvt)
+	scaleL := math.Cbrt(1.0 / math.Max(math.Max(r, g), math.Max(bl, 0.0)))
+
+	L = L / scaleL
+	C = C / scaleL
+
+	C = C * okToe(L) / L
+	L = okToe(L)
+
+	// We can now compute v and s.
+	v = L / Lv
+	s = (S0 + Tmax) * Cv / ((Tmax * S0) + Tmax*k*Cv)
+	return
+}
+
+// Okhsv creates a new Color given a Hue in [0..360], a Saturation [0..1],
+// and a Value in [0..1] in the Okhsv color space.
+func Okhsv(h, s, v float64) Color {
+	if v <= 0.0 {
+		return Color{0, 0, 0}
+	}
+
+	H := 0.01745329251994329576 * h // Deg2Rad
+	a := math.Cos(H)
+	b := math.Sin(H)
+
+	lcusp, ccusp := okFindCusp(a, b)
+	Smax, Tmax := ccusp/lcusp, ccusp/(1-lcusp)
+	const S0 = 0.5
+	k := 1 - S0/Smax
+
+	// First we compute L and V as if the gamut is a perfect triangle.
+	// L, C when v == 1:
+	Lv := 1 - s*S0/(S0+Tmax-Tmax*k*s)
+	Cv := s * Tmax * S0 / (S0 + Tmax - Tmax*k*s)
+
+	L := v * Lv
+	C := v * Cv
+
+	// Then we compensate for both toe and the curved top part of the triangle.
+	Lvt := okToeInv(Lv)
+	Cvt := Cv * Lvt / Lv
+
+	Lnew := okToeInv(L)
+	C = C * Lnew / L
+	L = Lnew
+
+	r, g, bl := okLabToLinearRgb(Lvt, a*Cvt, b*Cvt)
+	scaleL := math.Cbrt(1.0 / math.Max(math.Max(r, g), math.Max(bl, 0.0)))
+
+	L = L * scaleL
+	C = C * scaleL
+
+	return LinearRgb(okLabToLinearRgb(L, C*a, C*b))
+}
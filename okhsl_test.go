@@ -0,0 +1,97 @@
+package colorful
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+)
+
+// Reference values of Ottosson's reference implementation, ok_color.h from
+// https://bottosson.github.io/posts/colorpicker/. Red, lime, blue, white and
+// black are also in the test suite of https://colorjs.io, which ports it.
+var okhslvals = []struct {
+	hex   string
+	okhsl [3]float64
+	okhsv [3]float64
+}{
+	{"#ff0000", [3]float64{29.2338851923426, 1.0000000001433997, 0.5680846525040862}, [3]float64{29.2338851923426, 0.9995219692256989, 1.0000000001685625}},
+	{"#00ff00", [3]float64{142.49533888780996, 0.999999970072879, 0.8445289645307816}, [3]float64{142.49533888780996, 0.9999997210415701, 0.9999999884428645}},
+	{"#0000ff", [3]float64{264.052020638055, 0.9999999948631133, 0.3665653394260194}, [3]float64{264.052020638055, 0.9999910912349018, 0.9999999646150918}},
+	{"#ffffff", [3]float64{0.0, 0.0, 1.0}, [3]float64{0.0, 0.0, 1.0}},
+	{"#000000", [3]float64{0.0, 0.0, 0.0}, [3]float64{0.0, 0.0, 0.0}},
+	// Near the cusp, where the lightness of the most saturated color of the hue is high.
+	{"#ffff00", [3]float64{109.76923207652122, 1.0000000336324515, 0.9627043968088945}, [3]float64{109.76923207652122, 1.0000004467649035, 1.0000000319591924}},
+	{"#ff8800", [3]float64{56.45846026177072, 1.0000001626236206, 0.7025746183238603}, [3]float64{56.45846026177072, 1.0000003010015597, 1.0000000092258172}},
+	// Dark and saturated.
+	{"#400000", [3]float64{29.23388519234265, 0.9996845065444508, 0.1301194456433483}, [3]float64{29.23388519234265, 0.9995219692256989, 0.2375306469449845}},
+	{"#123456", [3]float64{251.16844905341424, 0.6914885375325026, 0.21891776206695807}, [3]float64{251.16844905341424, 0.7951527726062498, 0.34021453842689137}},
+	// Where the approximation of the gamut overshoots the most, see TestOkhslRoundtrip.
+	{"#0022aa", [3]float64{264.0328772365429, 1.004521157987761, 0.2660854193474918}, [3]float64{264.0328772365429, 1.011066766738551, 0.659213431277254}},
+	// Less saturated.
+	{"#3cb371", [3]float64{154.9990155674521, 0.8712595911370016, 0.6328919644982476}, [3]float64{154.9990155674521, 0.7841287423330364, 0.7246704500887214}},
+	{"#c080ff", [3]float64{305.4735461806792, 0.999986287020699, 0.6711113980703403}, [3]float64{305.4735461806792, 0.6417954797896033, 0.999999939886694}},
+	{"#7f6f5a", [3]float64{74.42075823798709, 0.2215508039300906, 0.4793716433024109}, [3]float64{74.42075823798709, 0.2365738517314025, 0.527419400160959}},
+}
+
+func TestOkhslReference(t *testing.T) {
+	for _, tt := range okhslvals {
+		compareTuple(t, pack(fromHex(tt.hex).Okhsl()), tt.okhsl, "OkhslFromHex", tt.hex)
+		compareTuple(t, pack(fromHex(tt.hex).Okhsv()), tt.okhsv, "OkhsvFromHex", tt.hex)
+		compareHex(t, Okhsl(unpack(tt.okhsl)).Hex(), tt.hex, "OkhslToHex", tt.hex)
+		compareHex(t, Okhsv(unpack(tt.okhsv)).Hex(), tt.hex, "OkhsvToHex", tt.hex)
+	}
+}
+
+// The gamut approximations are only exact up to about 1e-6.
+const okhslTestDelta = 0.00001
+
+// The saturations of the reference implementation overshoot 1 for some dark
+// blues, by up to 0.46% in Okhsl for #0022aa and 1.19% in Okhsv for #0033ee,
+// because its estimate of the gamut is least accurate there. A broken port of
+// the gamut computation overshoots by much more.
+const (
+	okhslMaxS = 1.0046
+	okhsvMaxS = 1.0119
+)
+
+func compareRgbEps(t *testing.T, result Color, expected [3]float64, method string, hex string) {
+	if math.Abs(result.R-expected[0]) > okhslTestDelta ||
+		math.Abs(result.G-expected[1]) > okhslTestDelta ||
+		math.Abs(result.B-expected[2]) > okhslTestDelta {
+		t.Errorf("result: %v expected: %v, testing %s with test case %s", result, expected, method, hex)
+	}
+}
+
+// Every color of the HSLuv snapshot needs to survive a round-trip through
+// Okhsl and Okhsv, which exercises the gamut cusp computation for all hues.
+func TestOkhslRoundtrip(t *testing.T) {
+	snapshotFile, err := os.Open("hsluv-snapshot-rev4.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer snapshotFile.Close()
+
+	snapshot := make(mapping)
+	if err = json.NewDecoder(snapshotFile).Decode(&snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	for hex, colorValues := range snapshot {
+		c := Color{colorValues.Rgb[0], colorValues.Rgb[1], colorValues.Rgb[2]}
+
+		h, s, l := c.Okhsl()
+		if s < 0 || s > okhslMaxS || l < 0 || l > 1 {
+			t.Errorf("result: %v out of range, testing OkhslFromRGB with test case %s", []float64{h, s, l}, hex)
+		}
+		compareHex(t, Okhsl(h, s, l).Hex(), hex, "OkhslRoundtrip", hex)
+		compareRgbEps(t, Okhsl(h, s, l), colorValues.Rgb, "OkhslToRGB", hex)
+
+		h, s, v := c.Okhsv()
+		if s < 0 || s > okhsvMaxS || v < 0 || v > 1+okhslTestDelta {
+			t.Errorf("result: %v out of range, testing OkhsvFromRGB with test case %s", []float64{h, s, v}, hex)
+		}
+		compareHex(t, Okhsv(h, s, v).Hex(), hex, "OkhsvRoundtrip", hex)
+		compareRgbEps(t, Okhsv(h, s, v), colorValues.Rgb, "OkhsvToRGB", hex)
+	}
+}
@@ -0,0 +1,355 @@
+package colorful
+
+import (
+	"math"
+	"sort"
+)
+
+// A Palette is a fixed set of colors, indexed for quickly finding the colors
+// closest to any given color, e.g. for mapping the pixels of an image to a
+// brand palette. Searching is logarithmic in the size of the palette for well
+// spread colors, where a linear scan with Color.Nearest is linear.
+//
+// There are two kinds of index. NewPalette builds a k-d tree over the colors
+// in one of a few color spaces, and measures the Euclidean distance in there.
+// NewPaletteMetric builds a vantage-point tree, which works with any
+// DistanceFunc that is a metric, but needs many more distance computations.
+//
+// A Palette is not modified by searching, so it is safe for concurrent use.
+type Palette struct {
+	colors []Color
+
+	// The k-d tree, see kdBuild.
+	space  PaletteSpace
+	points [][3]float64
+	axes   []uint8
+
+	// The vantage-point tree, see vpBuild.
+	dist   DistanceFunc
+	slack  float64
+	radius []float64
+
+	// Index into colors for every node of either tree.
+	idx []int
+}
+
+// A PaletteSpace is a color space which NewPalette can index colors in.
+type PaletteSpace int
+
+const (
+	// Distances are those of DistanceLab.
+	PaletteLab PaletteSpace = iota
+	// Distances are those of DistanceOkLab.
+	PaletteOkLab
+	// Distances are those of DistanceLinearRgb.
+	PaletteLinearRgb
+)
+
+func (s PaletteSpace) point(col Color) (p [3]float64) {
+	switch s {
+	case PaletteOkLab:
+		p[0], p[1], p[2] = col.OkLab()
+	case PaletteLinearRgb:
+		p[0], p[1], p[2] = col.LinearRgb()
+	default:
+		p[0], p[1], p[2] = col.Lab()
+	}
+	return
+}
+
+// NewPalette indexes the colors in a k-d tree in the given space, such that
+// the distances are Euclidean in that space.
+func NewPalette(colors []Color, space PaletteSpace) *Palette {
+	p := &Palette{
+		colors: append([]Color(nil), colors...),
+		space:  space,
+		points: make([][3]float64, len(colors)),
+		axes:   make([]uint8, len(colors)),
+		idx:    make([]int, len(colors)),
+	}
+	for i, col := range colors {
+		p.points[i] = space.point(col)
+		p.idx[i] = i
+	}
+	p.kdBuild(0, len(colors))
+	return p
+}
+
+// NewPaletteMetric indexes the colors in a vantage-point tree using the given
+// distance. The search relies on the triangle inequality to skip colors, so
+// the results are exact only if dist is a metric, see NewPaletteMetricEx for
+// the others.
+func NewPaletteMetric(colors []Color, dist DistanceFunc) *Palette {
+	return NewPaletteMetricEx(colors, dist, 1.0)
+}
+
+// NewPaletteMetricEx is like NewPaletteMetric, but for distances which are
+// not quite a metric, like DistanceCIEDE2000, which can violate the triangle
+// inequality by a lot for colors far apart. The search then skips colors
+// less eagerly, assuming the triangle inequality only holds when the distance
+// is multiplied by slack. For DistanceCIEDE2000, a slack of 2 finds the
+// closest color in all our tests, at about twice the distance computations.
+func NewPaletteMetricEx(colors []Color, dist DistanceFunc, slack float64) *Palette {
+	p := &Palette{
+		colors: append([]Color(nil), colors...),
+		dist:   dist,
+		slack:  slack,
+		radius: make([]float64, len(colors)),
+		idx:    make([]int, len(colors)),
+	}
+	for i := range colors {
+		p.idx[i] = i
+	}
+	p.vpBuild(0, len(colors), make([]float64, len(colors)))
+	return p
+}
+
+// Colors returns the colors of the palette, in the original order, which is
+// what the indices returned by Nearest and KNearest refer to.
+func (p *Palette) Colors() []Color {
+	return append([]Color(nil), p.colors...)
+}
+
+// Nearest returns the index of the color of the palette which is closest to
+// col, and the distance to it. It returns -1 for an empty palette. With a
+// metric palette, col is passed as the first color to the distance.
+func (p *Palette) Nearest(col Color) (idx int, dist float64) {
+	if p.dist != nil {
+		idxs, dists := p.KNearest(col, 1)
+		if len(idxs) == 0 {
+			return -1, 0
+		}
+		return idxs[0], dists[0]
+	}
+
+	q := p.space.point(col)
+	node, d2 := p.kdNearest(0, len(p.idx), q, -1, math.Inf(+1))
+	if node < 0 {
+		return -1, 0
+	}
+	return p.idx[node], math.Sqrt(d2)
+}
+
+// KNearest returns the indices of the k colors of the palette closest to col,
+// and the distances to them, closest first. If the palette has fewer than k
+// colors, all of them are returned.
+func (p *Palette) KNearest(col Color, k int) (idxs []int, dists []float64) {
+	if k > len(p.idx) {
+		k = len(p.idx)
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	best := &neighbors{idxs: make([]int, 0, k), dists: make([]float64, 0, k), k: k}
+	if p.dist != nil {
+		p.vpSearch(0, len(p.idx), col, best)
+		return best.idxs, best.dists
+	}
+
+	// The k-d tree works on squared distances, so take the roots in the end.
+	p.kdSearch(0, len(p.idx), p.space.point(col), best)
+	for i := range best.dists {
+		best.dists[i] = math.Sqrt(best.dists[i])
+	}
+	return best.idxs, best.dists
+}
+
+// neighbors is the list of the k closest colors found so far, ordered by distance.
+type neighbors struct {
+	idxs  []int
+	dists []float64
+	k     int
+}
+
+// worst is the distance a color needs to beat to get into the list.
+func (n *neighbors) worst() float64 {
+	if len(n.dists) < n.k {
+		return math.Inf(+1)
+	}
+	return n.dists[len(n.dists)-1]
+}
+
+func (n *neighbors) add(idx int, d float64) {
+	if d >= n.worst() {
+		return
+	}
+	if len(n.dists) < n.k {
+		n.idxs = append(n.idxs, idx)
+		n.dists = append(n.dists, d)
+	}
+	// Insertion sort from the end, dropping the previously worst.
+	i := len(n.dists) - 1
+	for ; i > 0 && n.dists[i-1] > d; i-- {
+		n.idxs[i], n.dists[i] = n.idxs[i-1], n.dists[i-1]
+	}
+	n.idxs[i], n.dists[i] = idx, d
+}
+
+/// k-d tree ///
+////////////////
+// The tree is stored implicitly: the node of the range [lo, hi) is at its
+// middle, the left subtree is [lo, mid) and the right subtree (mid, hi).
+
+func (p *Palette) kdBuild(lo, hi int) {
+	if hi-lo <= 1 {
+		return
+	}
+
+	// Split along the axis with the largest spread.
+	min, max := p.points[lo], p.points[lo]
+	for _, pt := range p.points[lo+1 : hi] {
+		for a := range pt {
+			min[a] = math.Min(min[a], pt[a])
+			max[a] = math.Max(max[a], pt[a])
+		}
+	}
+	axis := 0
+	for a := 1; a < 3; a++ {
+		if max[a]-min[a] > max[axis]-min[axis] {
+			axis = a
+		}
+	}
+
+	sort.Sort(kdRange{p, lo, hi, axis})
+	mid := (lo + hi) / 2
+	p.axes[mid] = uint8(axis)
+	p.kdBuild(lo, mid)
+	p.kdBuild(mid+1, hi)
+}
+
+// kdRange sorts a range of the tree's points along an axis.
+type kdRange struct {
+	p            *Palette
+	lo, hi, axis int
+}
+
+func (r kdRange) Len() int { return r.hi - r.lo }
+func (r kdRange) Less(i, j int) bool {
+	return r.p.points[r.lo+i][r.axis] < r.p.points[r.lo+j][r.axis]
+}
+func (r kdRange) Swap(i, j int) {
+	i, j = r.lo+i, r.lo+j
+	r.p.points[i], r.p.points[j] = r.p.points[j], r.p.points[i]
+	r.p.idx[i], r.p.idx[j] = r.p.idx[j], r.p.idx[i]
+}
+
+func kdDist2(p, q [3]float64) float64 {
+	return sq(p[0]-q[0]) + sq(p[1]-q[1]) + sq(p[2]-q[2])
+}
+
+// kdNearest returns the closest node to q in [lo, hi) if it's closer than the
+// given one, in squared distance. It is separate from kdSearch so that
+// Nearest doesn't allocate.
+func (p *Palette) kdNearest(lo, hi int, q [3]float64, best int, bestD2 float64) (int, float64) {
+	if lo >= hi {
+		return best, bestD2
+	}
+	mid := (lo + hi) / 2
+	if d2 := kdDist2(p.points[mid], q); d2 < bestD2 {
+		best, bestD2 = mid, d2
+	}
+
+	// Look on the side of q first, and only on the other if it can be closer.
+	diff := q[p.axes[mid]] - p.points[mid][p.axes[mid]]
+	if diff < 0 {
+		best, bestD2 = p.kdNearest(lo, mid, q, best, bestD2)
+		if sq(diff) < bestD2 {
+			best, bestD2 = p.kdNearest(mid+1, hi, q, best, bestD2)
+		}
+	} else {
+		best, bestD2 = p.kdNearest(mid+1, hi, q, best, bestD2)
+		if sq(diff) < bestD2 {
+			best, bestD2 = p.kdNearest(lo, mid, q, best, bestD2)
+		}
+	}
+	return best, bestD2
+}
+
+func (p *Palette) kdSearch(lo, hi int, q [3]float64, best *neighbors) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	best.add(p.idx[mid], kdDist2(p.points[mid], q))
+
+	diff := q[p.axes[mid]] - p.points[mid][p.axes[mid]]
+	if diff < 0 {
+		p.kdSearch(lo, mid, q, best)
+		if sq(diff) < best.worst() {
+			p.kdSearch(mid+1, hi, q, best)
+		}
+	} else {
+		p.kdSearch(mid+1, hi, q, best)
+		if sq(diff) < best.worst() {
+			p.kdSearch(lo, mid, q, best)
+		}
+	}
+}
+
+/// Vantage-point tree ///
+//////////////////////////
+// Also stored implicitly: the vantage point of the range [lo, hi) is at lo,
+// the colors closer to it than its radius are in [lo+1, m) and the others in
+// [m, hi), with m = lo+1 + (hi-lo-1)/2.
+
+func (p *Palette) vpBuild(lo, hi int, scratch []float64) {
+	if hi-lo <= 1 {
+		return
+	}
+
+	// Use the middle color as vantage point, which is as good as a random one
+	// but deterministic, and sort the others by the distance to it.
+	p.idx[lo], p.idx[(lo+hi)/2] = p.idx[(lo+hi)/2], p.idx[lo]
+	vp := p.colors[p.idx[lo]]
+	for i := lo + 1; i < hi; i++ {
+		scratch[i] = p.dist(vp, p.colors[p.idx[i]])
+	}
+	sort.Sort(vpRange{p.idx[lo+1 : hi], scratch[lo+1 : hi]})
+
+	m := lo + 1 + (hi-lo-1)/2
+	p.radius[lo] = scratch[m]
+	p.vpBuild(lo+1, m, scratch)
+	p.vpBuild(m, hi, scratch)
+}
+
+// vpRange sorts colors by their distance to a vantage point.
+type vpRange struct {
+	idx   []int
+	dists []float64
+}
+
+func (r vpRange) Len() int           { return len(r.idx) }
+func (r vpRange) Less(i, j int) bool { return r.dists[i] < r.dists[j] }
+func (r vpRange) Swap(i, j int) {
+	r.idx[i], r.idx[j] = r.idx[j], r.idx[i]
+	r.dists[i], r.dists[j] = r.dists[j], r.dists[i]
+}
+
+func (p *Palette) vpSearch(lo, hi int, col Color, best *neighbors) {
+	if lo >= hi {
+		return
+	}
+	d := p.dist(col, p.colors[p.idx[lo]])
+	best.add(p.idx[lo], d)
+	if hi-lo == 1 {
+		return
+	}
+
+	// By the triangle inequality, the colors inside the radius are at least
+	// d - radius away from col, and those outside at least radius - d.
+	// Relaxed by the slack for distances which aren't quite metrics.
+	m := lo + 1 + (hi-lo-1)/2
+	r := p.radius[lo]
+	if d < r {
+		p.vpSearch(lo+1, m, col, best)
+		if d+p.slack*best.worst() >= r {
+			p.vpSearch(m, hi, col, best)
+		}
+	} else {
+		p.vpSearch(m, hi, col, best)
+		if d-p.slack*best.worst() <= r {
+			p.vpSearch(lo+1, m, col, best)
+		}
+	}
+}
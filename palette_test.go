@@ -0,0 +1,141 @@
+package colorful
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomColors(rng *rand.Rand, n int) []Color {
+	cols := make([]Color, n)
+	for i := range cols {
+		cols[i] = Color{rng.Float64(), rng.Float64(), rng.Float64()}
+	}
+	return cols
+}
+
+// Compares the index against a linear scan with the equivalent distance.
+func TestPaletteNearest(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	type paletteCase struct {
+		name string
+		pal  *Palette
+		dist DistanceFunc
+	}
+	palettes := []paletteCase{}
+	for _, n := range []int{1, 2, 3, 17, 256} {
+		cols := randomColors(rng, n)
+		palettes = append(palettes,
+			paletteCase{"Lab", NewPalette(cols, PaletteLab), DistanceFuncLab},
+			paletteCase{"OkLab", NewPalette(cols, PaletteOkLab), DistanceFuncOkLab},
+			paletteCase{"LinearRgb", NewPalette(cols, PaletteLinearRgb), DistanceFuncLinearRgb},
+			paletteCase{"Metric OkLab", NewPaletteMetric(cols, DistanceFuncOkLab), DistanceFuncOkLab},
+		)
+	}
+
+	for _, tt := range palettes {
+		cols := tt.pal.Colors()
+		for i := 0; i < 200; i++ {
+			q := Color{rng.Float64(), rng.Float64(), rng.Float64()}
+			want, wantD := q.Nearest(cols, tt.dist)
+			if idx, d := tt.pal.Nearest(q); idx != want || math.Abs(d-wantD) > 1e-12 {
+				t.Errorf("%v palette of %v: Nearest(%v) => (%v, %v), want (%v, %v)", tt.name, len(cols), q, idx, d, want, wantD)
+			}
+		}
+	}
+}
+
+func TestPaletteKNearest(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	cols := randomColors(rng, 100)
+	for _, pal := range []*Palette{NewPalette(cols, PaletteLab), NewPaletteMetric(cols, DistanceFuncLab)} {
+		for i := 0; i < 50; i++ {
+			q := Color{rng.Float64(), rng.Float64(), rng.Float64()}
+			idxs, dists := pal.KNearest(q, 5)
+			if len(idxs) != 5 || len(dists) != 5 {
+				t.Fatalf("KNearest(%v, 5) => %v, want 5 colors", q, idxs)
+			}
+
+			// Nothing outside of the result may be closer than the farthest in it.
+			in := map[int]bool{}
+			for j, idx := range idxs {
+				in[idx] = true
+				if d := q.DistanceLab(cols[idx]); math.Abs(d-dists[j]) > 1e-12 {
+					t.Errorf("KNearest(%v, 5) distance %v => %v, want %v", q, j, dists[j], d)
+				}
+				if j > 0 && dists[j] < dists[j-1] {
+					t.Errorf("KNearest(%v, 5) => %v, not sorted", q, dists)
+				}
+			}
+			for idx, c := range cols {
+				if !in[idx] && q.DistanceLab(c) < dists[4] {
+					t.Errorf("KNearest(%v, 5) => %v, missing %v", q, idxs, idx)
+				}
+			}
+		}
+	}
+
+	pal := NewPalette(cols[:3], PaletteOkLab)
+	if idxs, _ := pal.KNearest(Color{}, 10); len(idxs) != 3 {
+		t.Errorf("KNearest with k larger than the palette => %v, want all 3", idxs)
+	}
+	if idxs, _ := pal.KNearest(Color{}, 0); len(idxs) != 0 {
+		t.Errorf("KNearest(0) => %v, want none", idxs)
+	}
+}
+
+func TestPaletteEmpty(t *testing.T) {
+	for _, pal := range []*Palette{NewPalette(nil, PaletteLab), NewPaletteMetric(nil, DistanceFuncCIEDE2000)} {
+		if idx, _ := pal.Nearest(Color{}); idx != -1 {
+			t.Errorf("Nearest in empty palette => %v, want -1", idx)
+		}
+		if idxs, _ := pal.KNearest(Color{}, 3); len(idxs) != 0 {
+			t.Errorf("KNearest in empty palette => %v, want none", idxs)
+		}
+	}
+}
+
+// CIEDE2000 is not quite a metric, but with some slack the closest color is found.
+func TestPaletteMetricCIEDE2000(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for _, n := range []int{16, 256} {
+		cols := randomColors(rng, n)
+		pal := NewPaletteMetricEx(cols, DistanceFuncCIEDE2000, 2.0)
+		for i := 0; i < 500; i++ {
+			q := Color{rng.Float64(), rng.Float64(), rng.Float64()}
+			want, wantD := q.Nearest(cols, DistanceFuncCIEDE2000)
+			if idx, d := pal.Nearest(q); idx != want || d != wantD {
+				t.Errorf("Nearest(%v) => (%v, %v), want (%v, %v)", q, idx, d, want, wantD)
+			}
+		}
+	}
+}
+
+func benchmarkNearest(bench *testing.B, nearest func(Color) (int, float64)) {
+	rng := rand.New(rand.NewSource(1))
+	queries := randomColors(rng, 1024)
+	bench.ResetTimer()
+	for n := 0; n < bench.N; n++ {
+		nearest(queries[n%len(queries)])
+	}
+}
+
+func BenchmarkPaletteNearestLab(bench *testing.B) {
+	pal := NewPalette(randomColors(rand.New(rand.NewSource(2)), 256), PaletteLab)
+	benchmarkNearest(bench, pal.Nearest)
+}
+
+func BenchmarkLinearNearestLab(bench *testing.B) {
+	cols := randomColors(rand.New(rand.NewSource(2)), 256)
+	benchmarkNearest(bench, func(c Color) (int, float64) { return c.Nearest(cols, DistanceFuncLab) })
+}
+
+func BenchmarkPaletteNearestCIEDE2000(bench *testing.B) {
+	pal := NewPaletteMetricEx(randomColors(rand.New(rand.NewSource(2)), 256), DistanceFuncCIEDE2000, 2.0)
+	benchmarkNearest(bench, pal.Nearest)
+}
+
+func BenchmarkLinearNearestCIEDE2000(bench *testing.B) {
+	cols := randomColors(rand.New(rand.NewSource(2)), 256)
+	benchmarkNearest(bench, func(c Color) (int, float64) { return c.Nearest(cols, DistanceFuncCIEDE2000) })
+}
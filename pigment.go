@@ -0,0 +1,108 @@
+package colorful
+
+import "math"
+
+// Pigment mixing, unlike the other Blend functions, mixes colors like paint
+// rather than like light: blue and yellow give green, and mixing in black or
+// a strong pigment darkens quickly.
+//
+// The colors are upsampled to reflectance spectra with Reflectance, and mixed
+// using single-constant Kubelka-Munk theory, which models each pigment by
+// the ratio K/S of its absorption to its scattering. K/S values mix linearly.
+//
+// Source: Haase and Meyer, "Modeling pigmented materials for realistic image
+// synthesis", ACM Transactions on Graphics 11(4), 1992.
+
+// The lowest reflectance used, so that black does not absorb infinitely, and
+// the lowest luminance used for weighing the amount of a color, see pigmentConcentration.
+const (
+	pigmentMinReflectance = 1e-4
+	pigmentMinLuminance   = 0.02
+)
+
+// kubelkaMunkKs returns the K/S ratio of a pigment layer with the given reflectance.
+func kubelkaMunkKs(r float64) float64 {
+	r = clamp01(r)
+	if r < pigmentMinReflectance {
+		r = pigmentMinReflectance
+	}
+	return sq(1.0-r) / (2.0 * r)
+}
+
+// kubelkaMunkReflectance returns the reflectance of an opaque layer with the given K/S ratio.
+func kubelkaMunkReflectance(ks float64) float64 {
+	return 1.0 + ks - math.Sqrt(ks*ks+2.0*ks)
+}
+
+// BlendPigment blends two colors as if they were paints, using Kubelka-Munk
+// theory. t == 0 results in c1, t == 1 results in c2. Like in real paint,
+// the mix is not always halfway between the two colors at t == 0.5, since
+// some pigments are stronger than others. Unlike the other Blend functions,
+// this doesn't extrapolate: there is no negative amount of paint, so t is
+// clamped to [0..1]. See MixPigments for its cost.
+func (c1 Color) BlendPigment(c2 Color, t float64) Color {
+	t = clamp01(t)
+	return MixPigments([]Color{c1, c2}, []float64{1.0 - t, t})
+}
+
+// MixPigments mixes any number of colors as if they were paints, using
+// Kubelka-Munk theory. The weights are the relative amounts of each color and
+// need not sum up to one. Colors with a weight of zero or less are left out,
+// and if all are, the result is black. It panics if there are fewer weights
+// than colors. The result is clamped to the sRGB gamut.
+//
+// This is much slower than the other Blend functions: every call fits a
+// spectrum to each color with Reflectance, a Gauss-Newton search which very
+// saturated colors repeat along a path of up to 64 steps. Nothing is
+// cached between calls, so mixing the same colors over and over, like when
+// sampling a gradient, refits them every time.
+func MixPigments(colors []Color, weights []float64) Color {
+	if len(weights) < len(colors) {
+		panic("colorful: MixPigments needs a weight for each color")
+	}
+
+	total := 0.0
+	used, only := 0, 0
+	for i := range colors {
+		if weights[i] > 0.0 {
+			total += pigmentConcentration(weights[i], colors[i])
+			used++
+			only = i
+		}
+	}
+
+	if used == 0 {
+		return Color{}
+	}
+	// Nothing to mix, keep the color as is instead of roundtripping through a spectrum.
+	if used == 1 {
+		return colors[only]
+	}
+
+	var ks [len(cie1931Cmf)]float64
+	for i, col := range colors {
+		if weights[i] <= 0.0 {
+			continue
+		}
+		w := pigmentConcentration(weights[i], col) / total
+		for j, r := range col.Reflectance().Values {
+			ks[j] += w * kubelkaMunkKs(r)
+		}
+	}
+
+	values := make([]float64, len(ks))
+	for j := range ks {
+		values[j] = kubelkaMunkReflectance(ks[j])
+	}
+	return Xyz(NewSpectrum(380, 5, values).Xyz(SpectrumD65)).Clamped()
+}
+
+// pigmentConcentration returns how much of the color to put into the mix for
+// the given amount. Colors don't say how strong their pigment is, and without
+// that, dark colors absorb much more than real paints do, so that blue and
+// yellow mix to a dark cyan. Like spectral.js, we weigh the amount by the
+// color's luminance, but keep a floor so that black still has an effect.
+func pigmentConcentration(amount float64, col Color) float64 {
+	_, y, _ := col.Xyz()
+	return amount * math.Max(y, pigmentMinLuminance)
+}
@@ -0,0 +1,92 @@
+package colorful
+
+import (
+	"testing"
+)
+
+func TestBlendPigment(t *testing.T) {
+	blue := Color{0, 0, 1}
+	yellow := Color{1, 1, 0}
+
+	// The whole point: blue and yellow paint make green, not gray.
+	if h, c, _ := blue.BlendPigment(yellow, 0.5).Hcl(); h < 110 || h > 170 || c < 0.3 {
+		t.Errorf("Blue.BlendPigment(Yellow, 0.5) => hue %v chroma %v, want green", h, c)
+	}
+
+	// Black and white make a gray which is much darker than the light-like mix.
+	gray := Color{0, 0, 0}.BlendPigment(Color{1, 1, 1}, 0.5)
+	if h, c, l := gray.Hcl(); c > 0.01 || l > 0.3 || l <= 0.0 {
+		t.Errorf("Black.BlendPigment(White, 0.5) => (%v, %v, %v), want dark neutral gray", h, c, l)
+	}
+
+	// Mixing a color with itself doesn't change it.
+	for i, tt := range vals {
+		if c := tt.c.BlendPigment(tt.c, 0.3); !c.AlmostEqualRgb(tt.c) {
+			t.Errorf("%v. %v.BlendPigment(%v, 0.3) => %v, want %v", i, tt.c, tt.c, c, tt.c)
+		}
+	}
+
+	red := Color{0.8, 0.1, 0.1}
+
+	// There is no negative amount of paint, t is clamped instead of extrapolated.
+	if c := red.BlendPigment(blue, -0.5); c != red {
+		t.Errorf("Red.BlendPigment(Blue, -0.5) => %v, want %v", c, red)
+	}
+	if c := red.BlendPigment(blue, 1.5); c != blue {
+		t.Errorf("Red.BlendPigment(Blue, 1.5) => %v, want %v", c, blue)
+	}
+
+	// Lightness goes monotonically from one to the other.
+	prev, _, _ := red.Lab()
+	for t0 := 0.1; t0 <= 1.0; t0 += 0.1 {
+		l, _, _ := red.BlendPigment(Color{1, 1, 1}, t0).Lab()
+		if l < prev {
+			t.Errorf("Red.BlendPigment(White, %v) has lightness %v, darker than %v", t0, l, prev)
+		}
+		prev = l
+	}
+}
+
+func TestMixPigments(t *testing.T) {
+	colors := []Color{{0, 0, 1}, {1, 1, 0}, {1, 1, 1}}
+	if c := MixPigments(colors, []float64{0, 2, 0}); c != colors[1] {
+		t.Errorf("MixPigments with a single weight => %v, want %v", c, colors[1])
+	}
+	if c := MixPigments(colors, []float64{0, 0, 0}); c != (Color{}) {
+		t.Errorf("MixPigments without weights => %v, want black", c)
+	}
+
+	// Weights are relative.
+	c1 := MixPigments(colors, []float64{1, 1, 1})
+	c2 := MixPigments(colors, []float64{0.5, 0.5, 0.5})
+	if !c1.AlmostEqualRgb(c2) {
+		t.Errorf("MixPigments with weights 1 => %v, with weights 0.5 => %v", c1, c2)
+	}
+
+	// Two-way mixes are the same as BlendPigment.
+	if c, want := MixPigments(colors[:2], []float64{0.75, 0.25}), colors[0].BlendPigment(colors[1], 0.25); c != want {
+		t.Errorf("MixPigments(blue, yellow) => %v, want %v", c, want)
+	}
+
+	// Negative weights are left out like zero ones.
+	if c := MixPigments(colors, []float64{-1, 2, -3}); c != colors[1] {
+		t.Errorf("MixPigments with negative weights => %v, want %v", c, colors[1])
+	}
+
+	// Missing weights are a programming error.
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("MixPigments with fewer weights than colors didn't panic")
+			}
+		}()
+		MixPigments(colors, []float64{1, 1})
+	}()
+
+	// Adding white lightens the mix.
+	l1, _, _ := MixPigments(colors[:2], []float64{1, 1}).Lab()
+	l2, _, _ := c1.Lab()
+	if l2 <= l1 {
+		t.Errorf("Adding white to blue and yellow => lightness %v, want more than %v", l2, l1)
+	}
+}
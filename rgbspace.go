@@ -0,0 +1,258 @@
+package colorful
+
+import "math"
+
+// RGBSpace describes an RGB color space by the chromaticities of its
+// primaries, its white point and its transfer function. The Color type is
+// always sRGB; an RGBSpace is used to convert a Color into and out of other
+// RGB spaces, such as the wide-gamut Display P3 or Rec.2020, via CIE XYZ.
+//
+// Conversions between spaces with a different white point than sRGB's D65
+// apply a Bradford chromatic adaptation. RGBSpaces are immutable and must be
+// made with NewRGBSpace.
+type RGBSpace struct {
+	name             string
+	red, green, blue [2]float64
+	white            [3]float64
+	linearize        func(float64) float64
+	delinearize      func(float64) float64
+	toXyz, fromXyz   [3][3]float64
+	toD65, fromD65   [3][3]float64
+}
+
+// NewRGBSpace creates an RGB space from the given xy chromaticities of its
+// primaries, its white point as XYZ and its transfer functions. Linearize goes
+// from encoded to linear values, delinearize is its inverse.
+func NewRGBSpace(name string, red, green, blue [2]float64, white [3]float64, linearize, delinearize func(float64) float64) *RGBSpace {
+	s := &RGBSpace{
+		name:        name,
+		red:         red,
+		green:       green,
+		blue:        blue,
+		white:       white,
+		linearize:   linearize,
+		delinearize: delinearize,
+	}
+
+	// Columns are the XYZ of the primaries, scaled such that (1, 1, 1) maps to the white point.
+	// See http://www.brucelindbloom.com/Eqn_RGB_XYZ_Matrix.html
+	var p [3][3]float64
+	for j, xy := range [3][2]float64{red, green, blue} {
+		p[0][j] = xy[0] / xy[1]
+		p[1][j] = 1.0
+		p[2][j] = (1.0 - xy[0] - xy[1]) / xy[1]
+	}
+	sr, sg, sb := mat3Apply(mat3Inv(p), white[0], white[1], white[2])
+	for i := 0; i < 3; i++ {
+		s.toXyz[i] = [3]float64{p[i][0] * sr, p[i][1] * sg, p[i][2] * sb}
+	}
+	s.fromXyz = mat3Inv(s.toXyz)
+
+	s.toD65 = AdaptationMatrix(white, srgbWhite, Bradford)
+	s.fromD65 = AdaptationMatrix(srgbWhite, white, Bradford)
+	return s
+}
+
+// Name returns the name the space was created with.
+func (s *RGBSpace) Name() string {
+	return s.name
+}
+
+// Primaries returns the CIE xy chromaticities of the red, green and blue primaries.
+func (s *RGBSpace) Primaries() (red, green, blue [2]float64) {
+	return s.red, s.green, s.blue
+}
+
+// White returns the white point as CIE XYZ with Y = 1, like D65 and D50.
+func (s *RGBSpace) White() [3]float64 {
+	return s.white
+}
+
+// Linearize applies the transfer function from an encoded to a linear value.
+func (s *RGBSpace) Linearize(v float64) float64 {
+	return s.linearize(v)
+}
+
+// Delinearize applies the transfer function from a linear to an encoded value.
+func (s *RGBSpace) Delinearize(v float64) float64 {
+	return s.delinearize(v)
+}
+
+// LinearRgbToXyz converts linear RGB values in this space to CIE XYZ,
+// relative to the space's own white point.
+func (s *RGBSpace) LinearRgbToXyz(r, g, b float64) (x, y, z float64) {
+	return mat3Apply(s.toXyz, r, g, b)
+}
+
+// XyzToLinearRgb converts CIE XYZ, relative to the space's own white point,
+// to linear RGB values in this space.
+func (s *RGBSpace) XyzToLinearRgb(x, y, z float64) (r, g, b float64) {
+	return mat3Apply(s.fromXyz, x, y, z)
+}
+
+// ToXyz converts encoded RGB values in this space to CIE XYZ (D65), the same
+// XYZ that Color.Xyz uses.
+func (s *RGBSpace) ToXyz(r, g, b float64) (x, y, z float64) {
+	x, y, z = s.LinearRgbToXyz(s.Linearize(r), s.Linearize(g), s.Linearize(b))
+	return mat3Apply(s.toD65, x, y, z)
+}
+
+// FromXyz converts CIE XYZ (D65), the same XYZ that Color.Xyz uses, to
+// encoded RGB values in this space. The result is not clamped.
+func (s *RGBSpace) FromXyz(x, y, z float64) (r, g, b float64) {
+	r, g, b = s.XyzToLinearRgb(mat3Apply(s.fromD65, x, y, z))
+	return s.Delinearize(r), s.Delinearize(g), s.Delinearize(b)
+}
+
+// FromColor converts the given sRGB color to encoded RGB values in this space.
+// The result is not clamped, as sRGB is not contained in all spaces.
+func (s *RGBSpace) FromColor(col Color) (r, g, b float64) {
+	return s.FromXyz(col.Xyz())
+}
+
+// ToColor converts encoded RGB values in this space to an sRGB color.
+// WARNING: wide-gamut colors do not have corresponding valid sRGB values,
+// check the FAQ in the README if you're unsure.
+func (s *RGBSpace) ToColor(r, g, b float64) Color {
+	return Xyz(s.ToXyz(r, g, b))
+}
+
+// The white point which the library's sRGB matrices are derived from, the exact D65 of the sRGB standard.
+var srgbWhite = xyToWhiteRef(0.3127, 0.3290)
+
+func xyToWhiteRef(x, y float64) [3]float64 {
+	return [3]float64{x / y, 1.0, (1.0 - x - y) / y}
+}
+
+/// Transfer functions ///
+//////////////////////////
+
+// The transfer functions with a pure power segment mirror negative values,
+// so that out-of-gamut colors survive a round-trip.
+func gammaLinearize(gamma float64) func(float64) float64 {
+	return func(v float64) float64 {
+		return math.Copysign(math.Pow(math.Abs(v), gamma), v)
+	}
+}
+
+func gammaDelinearize(gamma float64) func(float64) float64 {
+	return func(v float64) float64 {
+		return math.Copysign(math.Pow(math.Abs(v), 1.0/gamma), v)
+	}
+}
+
+// linearTransfer is the transfer function of spaces which are already linear.
+func linearTransfer(v float64) float64 {
+	return v
+}
+
+// The ITU-R BT.709 and BT.2020 OETF, at the precision of BT.2020's 12-bit version.
+const (
+	rec709Alpha = 1.09929682680944
+	rec709Beta  = 0.018053968510807
+)
+
+func rec709Linearize(v float64) float64 {
+	if math.Abs(v) < 4.5*rec709Beta {
+		return v / 4.5
+	}
+	return math.Copysign(math.Pow((math.Abs(v)+rec709Alpha-1.0)/rec709Alpha, 1.0/0.45), v)
+}
+
+func rec709Delinearize(v float64) float64 {
+	if math.Abs(v) < rec709Beta {
+		return 4.5 * v
+	}
+	return math.Copysign(rec709Alpha*math.Pow(math.Abs(v), 0.45)-(rec709Alpha-1.0), v)
+}
+
+// ROMM RGB (ProPhoto) uses a 1.8 gamma with a short linear segment.
+func prophotoLinearize(v float64) float64 {
+	if math.Abs(v) < 16.0/512.0 {
+		return v / 16.0
+	}
+	return math.Copysign(math.Pow(math.Abs(v), 1.8), v)
+}
+
+func prophotoDelinearize(v float64) float64 {
+	if math.Abs(v) < 1.0/512.0 {
+		return 16.0 * v
+	}
+	return math.Copysign(math.Pow(math.Abs(v), 1.0/1.8), v)
+}
+
+/// Presets ///
+///////////////
+
+var (
+	// SRGB is the space of Color itself, IEC 61966-2-1.
+	SRGB = NewRGBSpace("sRGB",
+		[2]float64{0.64, 0.33}, [2]float64{0.30, 0.60}, [2]float64{0.15, 0.06},
+		srgbWhite, linearize, delinearize)
+
+	// DisplayP3 has the DCI-P3 primaries with the white point and transfer function of sRGB.
+	DisplayP3 = NewRGBSpace("Display P3",
+		[2]float64{0.680, 0.320}, [2]float64{0.265, 0.690}, [2]float64{0.150, 0.060},
+		srgbWhite, linearize, delinearize)
+
+	// AdobeRGB is Adobe RGB (1998).
+	AdobeRGB = NewRGBSpace("Adobe RGB (1998)",
+		[2]float64{0.64, 0.33}, [2]float64{0.21, 0.71}, [2]float64{0.15, 0.06},
+		srgbWhite, gammaLinearize(563.0/256.0), gammaDelinearize(563.0/256.0))
+
+	// Rec709 is ITU-R BT.709, with the sRGB primaries but the BT.709 transfer function.
+	Rec709 = NewRGBSpace("Rec.709",
+		[2]float64{0.64, 0.33}, [2]float64{0.30, 0.60}, [2]float64{0.15, 0.06},
+		srgbWhite, rec709Linearize, rec709Delinearize)
+
+	// Rec2020 is ITU-R BT.2020 for SDR content. For HDR, see the PQ and HLG functions.
+	Rec2020 = NewRGBSpace("Rec.2020",
+		[2]float64{0.708, 0.292}, [2]float64{0.170, 0.797}, [2]float64{0.131, 0.046},
+		srgbWhite, rec709Linearize, rec709Delinearize)
+
+	// ProPhotoRGB is ROMM RGB, which has a D50 white point.
+	ProPhotoRGB = NewRGBSpace("ProPhoto RGB",
+		[2]float64{0.734699, 0.265301}, [2]float64{0.159597, 0.840403}, [2]float64{0.036598, 0.000105},
+		xyToWhiteRef(0.3457, 0.3585), prophotoLinearize, prophotoDelinearize)
+
+	// ACEScg is the linear working space of the Academy Color Encoding System,
+	// with the AP1 primaries and a white point close to D60.
+	ACEScg = NewRGBSpace("ACEScg",
+		[2]float64{0.713, 0.293}, [2]float64{0.165, 0.830}, [2]float64{0.128, 0.044},
+		xyToWhiteRef(0.32168, 0.33767), linearTransfer, linearTransfer)
+)
+
+/// Matrix helpers ///
+//////////////////////
+
+func mat3Apply(m [3][3]float64, a, b, c float64) (x, y, z float64) {
+	x = m[0][0]*a + m[0][1]*b + m[0][2]*c
+	y = m[1][0]*a + m[1][1]*b + m[1][2]*c
+	z = m[2][0]*a + m[2][1]*b + m[2][2]*c
+	return
+}
+
+func mat3Mul(a, b [3][3]float64) (m [3][3]float64) {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[i][j] = a[i][0]*b[0][j] + a[i][1]*b[1][j] + a[i][2]*b[2][j]
+		}
+	}
+	return
+}
+
+func mat3Inv(m [3][3]float64) (inv [3][3]float64) {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	inv[0][0] = (m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det
+	inv[0][1] = (m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det
+	inv[0][2] = (m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det
+	inv[1][0] = (m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det
+	inv[1][1] = (m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det
+	inv[1][2] = (m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det
+	inv[2][0] = (m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det
+	inv[2][1] = (m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det
+	inv[2][2] = (m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det
+	return
+}
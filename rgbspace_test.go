@@ -0,0 +1,91 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+var rgbspaces = []*RGBSpace{SRGB, DisplayP3, AdobeRGB, Rec709, Rec2020, ProPhotoRGB, ACEScg}
+
+func TestRGBSpaceRoundtrip(t *testing.T) {
+	for _, s := range rgbspaces {
+		for i, tt := range vals {
+			if c := s.ToColor(s.FromColor(tt.c)); !c.AlmostEqualRgb(tt.c) {
+				t.Errorf("%v. %v.ToColor(%v.FromColor(%v)) => (%v), want %v (delta %v)", i, s.Name(), s.Name(), tt.c, c, tt.c, delta)
+			}
+		}
+	}
+}
+
+func TestRGBSpaceWhite(t *testing.T) {
+	for _, s := range rgbspaces {
+		if r, g, b := s.FromColor(Color{1, 1, 1}); math.Abs(r-1) > delta || math.Abs(g-1) > delta || math.Abs(b-1) > delta {
+			t.Errorf("%v.FromColor(white) => (%v, %v, %v), want (1, 1, 1)", s.Name(), r, g, b)
+		}
+		w := s.White()
+		if x, y, z := s.LinearRgbToXyz(1, 1, 1); math.Abs(x-w[0]) > 1e-12 || math.Abs(y-w[1]) > 1e-12 || math.Abs(z-w[2]) > 1e-12 {
+			t.Errorf("%v.LinearRgbToXyz(1, 1, 1) => (%v, %v, %v), want %v", s.Name(), x, y, z, w)
+		}
+	}
+}
+
+func TestRGBSpaceMatrices(t *testing.T) {
+	// The sRGB preset has to agree with the hard-coded matrices of the library.
+	for i, tt := range vals {
+		r, g, b := tt.c.LinearRgb()
+		x1, y1, z1 := LinearRgbToXyz(r, g, b)
+		x2, y2, z2 := SRGB.LinearRgbToXyz(r, g, b)
+		if math.Abs(x1-x2) > 1e-12 || math.Abs(y1-y2) > 1e-12 || math.Abs(z1-z2) > 1e-12 {
+			t.Errorf("%v. SRGB.LinearRgbToXyz(%v) => (%v, %v, %v), want (%v, %v, %v)", i, tt.c, x2, y2, z2, x1, y1, z1)
+		}
+
+		r1, g1, b1 := XyzToLinearRec2020(x1, y1, z1)
+		r2, g2, b2 := Rec2020.XyzToLinearRgb(x1, y1, z1)
+		if math.Abs(r1-r2) > 1e-12 || math.Abs(g1-g2) > 1e-12 || math.Abs(b1-b2) > 1e-12 {
+			t.Errorf("%v. Rec2020.XyzToLinearRgb(%v) => (%v, %v, %v), want (%v, %v, %v)", i, tt.c, r2, g2, b2, r1, g1, b1)
+		}
+	}
+}
+
+func TestRGBSpaceFromColor(t *testing.T) {
+	tests := []struct {
+		s    *RGBSpace
+		c    Color
+		want [3]float64
+	}{
+		{DisplayP3, Color{1, 0, 0}, [3]float64{0.917488, 0.200287, 0.138561}},
+		{DisplayP3, Color{0, 1, 0}, [3]float64{0.458402, 0.985265, 0.298295}},
+		{AdobeRGB, Color{1, 0, 0}, [3]float64{0.858592, 0, 0}},
+		{Rec709, Color{0.5, 0.5, 0.5}, [3]float64{0.450040, 0.450040, 0.450040}},
+	}
+	for i, tt := range tests {
+		r, g, b := tt.s.FromColor(tt.c)
+		if math.Abs(r-tt.want[0]) > 1e-5 || math.Abs(g-tt.want[1]) > 1e-5 || math.Abs(b-tt.want[2]) > 1e-5 {
+			t.Errorf("%v. %v.FromColor(%v) => (%v, %v, %v), want %v", i, tt.s.Name(), tt.c, r, g, b, tt.want)
+		}
+	}
+
+	// Wide-gamut colors are out of the sRGB gamut.
+	if c := DisplayP3.ToColor(1, 0, 0); c.IsValid() {
+		t.Errorf("DisplayP3.ToColor(1, 0, 0) => %v, want out of gamut", c)
+	}
+}
+
+func TestRGBSpaceAccessors(t *testing.T) {
+	s := NewRGBSpace("test", [2]float64{0.7, 0.3}, [2]float64{0.2, 0.7}, [2]float64{0.15, 0.05}, D50, gammaLinearize(2.2), gammaDelinearize(2.2))
+	if s.Name() != "test" {
+		t.Errorf("Name() => %q, want \"test\"", s.Name())
+	}
+	if r, g, b := s.Primaries(); r != [2]float64{0.7, 0.3} || g != [2]float64{0.2, 0.7} || b != [2]float64{0.15, 0.05} {
+		t.Errorf("Primaries() => (%v, %v, %v)", r, g, b)
+	}
+	if w := s.White(); w != D50 {
+		t.Errorf("White() => %v, want %v", w, D50)
+	}
+	if v := s.Linearize(0.5); math.Abs(v-math.Pow(0.5, 2.2)) > 1e-12 {
+		t.Errorf("Linearize(0.5) => %v, want %v", v, math.Pow(0.5, 2.2))
+	}
+	if v := s.Delinearize(s.Linearize(0.3)); math.Abs(v-0.3) > 1e-12 {
+		t.Errorf("Delinearize(Linearize(0.3)) => %v, want 0.3", v)
+	}
+}
@@ -33,6 +33,20 @@ type SoftPaletteSettings struct {
 // happens to fall outside of the color-space, which can only happen if you
 // specify a CheckColor function.
 func SoftPaletteEx(colorsCount int, settings SoftPaletteSettings) ([]Color, error) {
+	return softPalette(colorsCount, settings, lab_dist)
+}
+
+// SoftPaletteExBy is like SoftPaletteEx, but assigns the samples to the
+// closest mean using the given distance instead of the Euclidean distance in
+// L*a*b*. The means themselves are still averaged in L*a*b*. This is much
+// slower, since the samples need to be converted for every distance.
+func SoftPaletteExBy(colorsCount int, settings SoftPaletteSettings, dist DistanceFunc) ([]Color, error) {
+	return softPalette(colorsCount, settings, func(lab1, lab2 lab_t) float64 {
+		return dist(Lab(lab1.L, lab1.A, lab1.B), Lab(lab2.L, lab2.A, lab2.B))
+	})
+}
+
+func softPalette(colorsCount int, settings SoftPaletteSettings, distance func(lab1, lab2 lab_t) float64) ([]Color, error) {
 
 	// Checks whether it's a valid RGB and also fulfills the potentially provided constraint.
 	check := func(col lab_t) bool {
@@ -85,7 +99,7 @@ func SoftPaletteEx(colorsCount int, settings SoftPaletteSettings) ([]Color, erro
 			samples_used[isample] = false
 			mindist := math.Inf(+1)
 			for imean, mean := range means {
-				dist := lab_dist(sample, mean)
+				dist := distance(sample, mean)
 				if dist < mindist {
 					mindist = dist
 					clusters[isample] = imean
@@ -135,7 +149,7 @@ func SoftPaletteEx(colorsCount int, settings SoftPaletteSettings) ([]Color, erro
 				mindist := math.Inf(+1)
 				for isample, sample := range samples {
 					if !samples_used[isample] {
-						dist := lab_dist(sample, newmean)
+						dist := distance(sample, newmean)
 						if dist < mindist {
 							mindist = dist
 							newmean = sample
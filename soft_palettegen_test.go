@@ -63,3 +63,19 @@ func TestConstraint(t *testing.T) {
 		}
 	}
 }
+
+// Check whether a custom distance still gives enough valid colors.
+func TestSoftPaletteExBy(t *testing.T) {
+	pal, err := SoftPaletteExBy(8, SoftPaletteSettings{nil, 10, false}, DistanceFuncOkLab)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+	if len(pal) != 8 {
+		t.Errorf("Requested %v colors but got %v", 8, len(pal))
+	}
+	for icol, col := range pal {
+		if !col.IsValid() {
+			t.Errorf("Color %v in palette is invalid: %v", icol, col)
+		}
+	}
+}
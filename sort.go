@@ -64,15 +64,14 @@ type edgeIdxs [2]int
 // between the two vertices.
 type edgeDistance map[edgeIdxs]float64
 
-// allToAllDistancesCIEDE2000 computes the CIEDE2000 distance between each pair of
-// colors.  It returns a map from a pair of indices (u, v) with u < v to a
-// distance.
-func allToAllDistancesCIEDE2000(cs []Color) edgeDistance {
+// allToAllDistances computes the distance between each pair of colors.  It
+// returns a map from a pair of indices (u, v) with u < v to a distance.
+func allToAllDistances(cs []Color, dist DistanceFunc) edgeDistance {
 	nc := len(cs)
 	m := make(edgeDistance, nc*nc)
 	for u := 0; u < nc-1; u++ {
 		for v := u + 1; v < nc; v++ {
-			m[edgeIdxs{u, v}] = cs[u].DistanceCIEDE2000(cs[v])
+			m[edgeIdxs{u, v}] = dist(cs[u], cs[v])
 		}
 	}
 	return m
@@ -149,8 +148,14 @@ func traverseMST(mst map[edgeIdxs]struct{}, root int) []int {
 
 // Sorted sorts a list of Color values.  Sorting is not a well-defined operation
 // for colors so the intention here primarily is to order colors so that the
-// transition from one to the next is fairly smooth.
+// transition from one to the next is fairly smooth.  It uses DistanceCIEDE2000,
+// see SortedBy for using another distance.
 func Sorted(cs []Color) []Color {
+	return SortedBy(cs, DistanceFuncCIEDE2000)
+}
+
+// SortedBy is like Sorted, but uses the given distance between colors.
+func SortedBy(cs []Color, dist DistanceFunc) []Color {
 	// Do nothing in trivial cases.
 	newCs := make([]Color, len(cs))
 	if len(cs) < 2 {
@@ -159,7 +164,7 @@ func Sorted(cs []Color) []Color {
 	}
 
 	// Compute the distance from each color to every other color.
-	dists := allToAllDistancesCIEDE2000(cs)
+	dists := allToAllDistances(cs, dist)
 
 	// Produce a list of edges in increasing order of the distance between
 	// their vertices.
@@ -173,7 +178,7 @@ func Sorted(cs []Color) []Color {
 	var dIdx int             // Index of darkest color
 	light := math.MaxFloat64 // Lightness of darkest color (distance from black)
 	for i, c := range cs {
-		d := black.DistanceCIEDE2000(c)
+		d := dist(black, c)
 		if d < light {
 			dIdx = i
 			light = d
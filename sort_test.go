@@ -1,6 +1,9 @@
 package colorful
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 // TestSortSimple tests the sorting of a small set of colors.
 func TestSortSimple(t *testing.T) {
@@ -27,3 +30,37 @@ func TestSortSimple(t *testing.T) {
 		}
 	}
 }
+
+// TestSortedBy tests that the distance is used, and that Sorted uses CIEDE2000.
+func TestSortedBy(t *testing.T) {
+	in := []Color{
+		Color{R: 0.75, G: 0.0, B: 0},
+		Color{R: 0.0, G: 0.0, B: 0.25},
+		Color{R: 0.25, G: 0.0, B: 0},
+		Color{R: 0.0, G: 0.0, B: 0.75},
+		Color{R: 0.50, G: 0.0, B: 0},
+		Color{R: 0.0, G: 0.0, B: 0.50},
+	}
+	out1, out2 := Sorted(in), SortedBy(in, Color.DistanceCIEDE2000)
+	for i := range out1 {
+		if out1[i] != out2[i] {
+			t.Fatalf("Sorted and SortedBy CIEDE2000 differ at %v: %v and %v", i, out1[i], out2[i])
+		}
+	}
+
+	// With a distance on the green channel only, the colors are sorted by green.
+	in = []Color{
+		Color{R: 0.3, G: 0.50, B: 0},
+		Color{R: 0.9, G: 0.00, B: 0},
+		Color{R: 0.1, G: 0.75, B: 0},
+		Color{R: 0.5, G: 0.25, B: 0},
+		Color{R: 0.7, G: 1.00, B: 0},
+	}
+	greenOnly := func(c1, c2 Color) float64 { return math.Abs(c1.G - c2.G) }
+	out := SortedBy(in, greenOnly)
+	for i := 1; i < len(out); i++ {
+		if out[i-1].G >= out[i].G {
+			t.Fatalf("Colors not sorted by green: %v", out)
+		}
+	}
+}
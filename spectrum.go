@@ -0,0 +1,162 @@
+package colorful
+
+import (
+	"math"
+	"sort"
+)
+
+// Spectrum is a sampled spectral curve, for example the reflectance of a
+// surface as measured by a spectrophotometer, or the spectral power
+// distribution of a light source. Wavelengths are in nm and have to be
+// strictly increasing, with one value for each of them. Methods panic on
+// spectra which aren't.
+type Spectrum struct {
+	Wavelengths []float64
+	Values      []float64
+}
+
+// NewSpectrum creates a spectrum from values sampled on a regular grid,
+// beginning at start nm and every step nm after that.
+func NewSpectrum(start, step float64, values []float64) Spectrum {
+	s := Spectrum{
+		Wavelengths: make([]float64, len(values)),
+		Values:      make([]float64, len(values)),
+	}
+	for i, v := range values {
+		s.Wavelengths[i] = start + float64(i)*step
+		s.Values[i] = v
+	}
+	return s
+}
+
+// At returns the value of the spectrum at the given wavelength in nm,
+// interpolating linearly between samples. Outside of the sampled range, the
+// nearest sample is repeated, as recommended by CIE 15:2018.
+func (s Spectrum) At(wavelength float64) float64 {
+	s.check()
+	return s.at(wavelength)
+}
+
+// check panics unless the spectrum has as many values as wavelengths, and
+// the wavelengths are strictly increasing.
+func (s Spectrum) check() {
+	if len(s.Wavelengths) != len(s.Values) {
+		panic("colorful: Spectrum needs as many Values as Wavelengths")
+	}
+	for i := 1; i < len(s.Wavelengths); i++ {
+		// Written such that NaNs are rejected, too.
+		if !(s.Wavelengths[i] > s.Wavelengths[i-1]) {
+			panic("colorful: Spectrum needs strictly increasing Wavelengths")
+		}
+	}
+}
+
+// at is At for spectra which have already been checked.
+func (s Spectrum) at(wavelength float64) float64 {
+	n := len(s.Wavelengths)
+	if n == 0 {
+		return 0.0
+	}
+
+	i := sort.SearchFloat64s(s.Wavelengths, wavelength)
+	if i == 0 {
+		return s.Values[0]
+	}
+	if i == n {
+		return s.Values[n-1]
+	}
+	t := (wavelength - s.Wavelengths[i-1]) / (s.Wavelengths[i] - s.Wavelengths[i-1])
+	return s.Values[i-1] + t*(s.Values[i]-s.Values[i-1])
+}
+
+// Resample returns the spectrum sampled from start to end nm, inclusive,
+// every step nm. It panics unless step is positive and end is not before start.
+func (s Spectrum) Resample(start, end, step float64) Spectrum {
+	// Written such that NaNs are rejected, too.
+	if !(step > 0.0) || !(end >= start) {
+		panic("colorful: Resample needs a positive step and start <= end")
+	}
+	s.check()
+	n := int(math.Floor((end-start)/step+1e-9)) + 1
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = s.at(start + float64(i)*step)
+	}
+	return NewSpectrum(start, step, values)
+}
+
+// Xyz integrates the spectrum as the reflectance (or transmittance) of a
+// sample lit by the given illuminant, seen by the CIE 1931 2° observer.
+// The result is normalized such that a perfect white has Y = 1, so that
+// under SpectrumD65 it can be used directly with the Xyz function and the
+// D65 white reference.
+func (s Spectrum) Xyz(illuminant Spectrum) (x, y, z float64) {
+	return s.XyzObserver(illuminant, CIE1931)
+}
+
+// XyzObserver is like Xyz, but for the given standard observer.
+func (s Spectrum) XyzObserver(illuminant Spectrum, obs Observer) (x, y, z float64) {
+	s.check()
+	illuminant.check()
+	cmf := observerCmf(obs)
+	k := 0.0
+	for i, c := range cmf {
+		wl := 380.0 + 5.0*float64(i)
+		e := illuminant.at(wl)
+		r := s.at(wl)
+		x += r * e * c[0]
+		y += r * e * c[1]
+		z += r * e * c[2]
+		k += e * c[1]
+	}
+	return x / k, y / k, z / k
+}
+
+// WhiteRef integrates the spectrum as the power distribution of a light
+// source, seen by the given standard observer, and returns it as a white
+// reference with Y = 1.
+func (s Spectrum) WhiteRef(obs Observer) (wref [3]float64) {
+	s.check()
+	for i, c := range observerCmf(obs) {
+		e := s.at(380.0 + 5.0*float64(i))
+		wref[0] += e * c[0]
+		wref[1] += e * c[1]
+		wref[2] += e * c[2]
+	}
+	return [3]float64{wref[0] / wref[1], 1.0, wref[2] / wref[1]}
+}
+
+func observerCmf(obs Observer) *[81][3]float64 {
+	if obs == CIE1964 {
+		return &cie1964Cmf
+	}
+	return &cie1931Cmf
+}
+
+// ColorMatchingFunctions returns the color matching functions x̄, ȳ and z̄ of
+// the given standard observer, sampled every 5 nm from 380 nm to 780 nm.
+func ColorMatchingFunctions(obs Observer) (xbar, ybar, zbar Spectrum) {
+	cmf := observerCmf(obs)
+	var xs, ys, zs [len(cie1931Cmf)]float64
+	for i, c := range cmf {
+		xs[i], ys[i], zs[i] = c[0], c[1], c[2]
+	}
+	return NewSpectrum(380, 5, xs[:]), NewSpectrum(380, 5, ys[:]), NewSpectrum(380, 5, zs[:])
+}
+
+// SpectrumD65 is the relative spectral power distribution of the CIE
+// standard illuminant D65, from 380 nm to 780 nm.
+var SpectrumD65 = NewSpectrum(380, 5, d65Spd[:])
+
+// SpectrumA is the relative spectral power distribution of the CIE standard
+// illuminant A, a tungsten filament lamp, from 380 nm to 780 nm.
+var SpectrumA = func() Spectrum {
+	// A is defined by Planck's law, with the second radiation constant of 1931.
+	values := make([]float64, len(cie1931Cmf))
+	for i := range values {
+		wl := 380.0 + 5.0*float64(i)
+		values[i] = 100.0 * math.Pow(560.0/wl, 5.0) *
+			math.Expm1(1.435e7/(2848.0*560.0)) / math.Expm1(1.435e7/(2848.0*wl))
+	}
+	return NewSpectrum(380, 5, values)
+}()
@@ -0,0 +1,128 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpectrumAt(t *testing.T) {
+	s := Spectrum{Wavelengths: []float64{400, 500, 700}, Values: []float64{0.2, 0.4, 0.0}}
+	tests := []struct{ wl, want float64 }{
+		{300, 0.2}, {400, 0.2}, {450, 0.3}, {500, 0.4}, {550, 0.3}, {700, 0.0}, {800, 0.0},
+	}
+	for i, tt := range tests {
+		if v := s.At(tt.wl); math.Abs(v-tt.want) > 1e-12 {
+			t.Errorf("%v. At(%v) => %v, want %v", i, tt.wl, v, tt.want)
+		}
+	}
+
+	r := s.Resample(400, 700, 50)
+	if len(r.Values) != 7 || r.Wavelengths[6] != 700 || math.Abs(r.Values[3]-0.3) > 1e-12 {
+		t.Errorf("Resample(400, 700, 50) => %v", r)
+	}
+
+	if r := s.Resample(500, 500, 10); len(r.Values) != 1 || r.Values[0] != 0.4 {
+		t.Errorf("Resample(500, 500, 10) => %v", r)
+	}
+	for _, tt := range [][3]float64{{400, 700, 0}, {400, 700, -50}, {700, 400, 50}, {400, 700, math.NaN()}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Resample(%v, %v, %v) didn't panic", tt[0], tt[1], tt[2])
+				}
+			}()
+			s.Resample(tt[0], tt[1], tt[2])
+		}()
+	}
+
+	if v := (Spectrum{}).At(500); v != 0 {
+		t.Errorf("Empty Spectrum.At(500) => %v, want 0", v)
+	}
+
+	bad := []Spectrum{
+		{Wavelengths: []float64{400, 500, 700}, Values: []float64{0.2, 0.4}},
+		{Wavelengths: []float64{400, 700, 500}, Values: []float64{0.2, 0.4, 0.0}},
+		{Wavelengths: []float64{400, 500, 500}, Values: []float64{0.2, 0.4, 0.0}},
+	}
+	for i, b := range bad {
+		for name, f := range map[string]func(){
+			"At":       func() { b.At(450) },
+			"Resample": func() { b.Resample(400, 700, 50) },
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%v. %v of %v didn't panic", i, name, b)
+					}
+				}()
+				f()
+			}()
+		}
+	}
+}
+
+func TestColorMatchingFunctions(t *testing.T) {
+	// The color matching functions are normalized such that an equal energy
+	// spectrum has the same X, Y and Z.
+	for _, obs := range []Observer{CIE1931, CIE1964} {
+		xbar, ybar, zbar := ColorMatchingFunctions(obs)
+		var sx, sy, sz float64
+		for i := range xbar.Values {
+			sx += xbar.Values[i]
+			sy += ybar.Values[i]
+			sz += zbar.Values[i]
+		}
+		if math.Abs(sx/sy-1) > 5e-4 || math.Abs(sz/sy-1) > 5e-4 {
+			t.Errorf("ColorMatchingFunctions(%v) sums => (%v, %v, %v), want equal", obs, sx, sy, sz)
+		}
+	}
+	if _, ybar, _ := ColorMatchingFunctions(CIE1931); ybar.At(555) != 1.0 {
+		t.Errorf("CIE 1931 ybar peak => %v, want 1", ybar.At(555))
+	}
+}
+
+func TestSpectrumWhiteRef(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Spectrum
+		obs  Observer
+	}{
+		{"D65", SpectrumD65, CIE1931},
+		{"D65", SpectrumD65, CIE1964},
+		{"A", SpectrumA, CIE1931},
+		{"A", SpectrumA, CIE1964},
+	}
+	for i, tt := range tests {
+		want, _ := StandardIlluminants[tt.name].WhiteRef(tt.obs)
+		if wref := tt.s.WhiteRef(tt.obs); math.Abs(wref[0]-want[0]) > 5e-4 || wref[1] != 1 || math.Abs(wref[2]-want[2]) > 5e-4 {
+			t.Errorf("%v. Spectrum%v.WhiteRef(%v) => %v, want %v", i, tt.name, tt.obs, wref, want)
+		}
+	}
+}
+
+func TestSpectrumXyz(t *testing.T) {
+	// A perfect reflector is the illuminant's white.
+	white := NewSpectrum(380, 10, []float64{1, 1})
+	x, y, z := white.Xyz(SpectrumD65)
+	if math.Abs(x-D65[0]) > 1e-3 || math.Abs(y-1) > 1e-12 || math.Abs(z-D65[2]) > 1e-3 {
+		t.Errorf("White.Xyz(D65) => (%v, %v, %v), want %v", x, y, z, D65)
+	}
+	if c := Xyz(white.Xyz(SpectrumD65)); !c.AlmostEqualRgb(Color{1, 1, 1}) {
+		t.Errorf("Xyz(White.Xyz(D65)) => %v, want white", c)
+	}
+
+	// A flat gray is neutral at its reflectance.
+	gray := NewSpectrum(380, 400, []float64{0.18, 0.18})
+	x, y, z = gray.XyzObserver(SpectrumA, CIE1964)
+	wref := SpectrumA.WhiteRef(CIE1964)
+	l, a, b := XyzToLabWhiteRef(x, y, z, wref)
+	if math.Abs(y-0.18) > 1e-12 || math.Abs(a) > 1e-12 || math.Abs(b) > 1e-12 {
+		t.Errorf("Gray.XyzObserver(A, CIE1964) => Lab (%v, %v, %v), want neutral", l, a, b)
+	}
+
+	// A reflectance curve which only reflects long wavelengths is red.
+	red := Spectrum{Wavelengths: []float64{380, 580, 620, 780}, Values: []float64{0.05, 0.05, 0.9, 0.9}}
+	if h, c, _ := Xyz(red.Xyz(SpectrumD65)).Hcl(); (h > 60 && h < 340) || c < 0.3 {
+		t.Errorf("Red.Xyz(D65) => hue %v chroma %v, want a saturated red", h, c)
+	}
+}
@@ -0,0 +1,240 @@
+package colorful
+
+import (
+	"fmt"
+	"math"
+)
+
+// Spline interpolation draws a smooth curve through the stops of a Gradient,
+// where blending between neighboring stops makes visible kinks at each stop.
+// The curves are computed on the coordinates of a Lab-like space, see
+// GradientSettings.
+
+// A GradientInterpolation is the way a Gradient goes from stop to stop.
+type GradientInterpolation int
+
+const (
+	// Blends between the two neighboring stops, like the Blend methods do.
+	InterpolationLinear GradientInterpolation = iota
+	// A Catmull-Rom spline, which passes through the stops and is smooth,
+	// with the direction at each stop pointing from the previous to the next one.
+	InterpolationCatmullRom
+	// A natural cubic spline, which passes through the stops and is even
+	// smoother than Catmull-Rom, but can overshoot more.
+	InterpolationNatural
+	// A cubic B-spline, which passes through the first and last stop only and
+	// uses the others as control points, like the bezier mode of chroma.js.
+	// This is the smoothest, and does not overshoot.
+	InterpolationBSpline
+)
+
+var gradientInterpolations = [...]string{
+	InterpolationLinear:     "linear",
+	InterpolationCatmullRom: "catmull-rom",
+	InterpolationNatural:    "natural",
+	InterpolationBSpline:    "b-spline",
+}
+
+func (gi GradientInterpolation) valid() bool {
+	return 0 <= gi && int(gi) < len(gradientInterpolations)
+}
+
+// String returns the name of the interpolation, as used in JSON and YAML.
+func (gi GradientInterpolation) String() string {
+	if !gi.valid() {
+		return fmt.Sprintf("GradientInterpolation(%d)", int(gi))
+	}
+	return gradientInterpolations[gi]
+}
+
+// ParseGradientInterpolation returns the interpolation of the given name, as returned by String.
+func ParseGradientInterpolation(name string) (GradientInterpolation, error) {
+	for gi, n := range gradientInterpolations {
+		if n == name {
+			return GradientInterpolation(gi), nil
+		}
+	}
+	return 0, fmt.Errorf("gradient: unknown interpolation %q", name)
+}
+
+// splineSpace tells whether the space can be used for splines and lightness
+// correction, and which coordinate is the lightness.
+func (s GradientSpace) splineSpace() (lightness int, ok bool) {
+	switch s {
+	case GradientLab, GradientOkLab:
+		return 0, true
+	case GradientHcl:
+		return 2, true
+	}
+	return 0, false
+}
+
+func (s GradientSpace) coords(col Color) (p [3]float64) {
+	switch s {
+	case GradientOkLab:
+		p[0], p[1], p[2] = col.OkLab()
+	case GradientHcl:
+		p[0], p[1], p[2] = col.Hcl()
+	default:
+		p[0], p[1], p[2] = col.Lab()
+	}
+	return
+}
+
+func (s GradientSpace) fromCoords(p [3]float64) Color {
+	switch s {
+	case GradientOkLab:
+		return OkLab(p[0], p[1], p[2])
+	case GradientHcl:
+		// The curve can overshoot below zero chroma, which flips the hue.
+		return Hcl(math.Mod(math.Mod(p[0], 360.0)+360.0, 360.0), math.Max(p[1], 0.0), p[2])
+	}
+	return Lab(p[0], p[1], p[2])
+}
+
+// prepareSpline computes everything the splines need from the stops. Stops
+// at the same position split the gradient into independent runs.
+func (g *Gradient) prepareSpline() {
+	n := len(g.stops)
+	g.points = make([][3]float64, n)
+	g.runStart = make([]int, n)
+	g.runEnd = make([]int, n)
+	for i, s := range g.stops {
+		g.points[i] = g.space.coords(s.Color)
+	}
+
+	if g.space == GradientHcl {
+		g.unwrapHues()
+	}
+
+	for end := n - 1; end >= 0; end-- {
+		lo := end
+		for lo > 0 && g.stops[lo-1].Pos < g.stops[lo].Pos {
+			lo--
+		}
+		for i := lo; i <= end; i++ {
+			g.runStart[i], g.runEnd[i] = lo, end
+		}
+		if g.interp == InterpolationNatural {
+			g.naturalSpline(lo, end)
+		}
+		end = lo
+	}
+}
+
+// unwrapHues makes the hues of neighboring stops go the short way around, and
+// gives gray stops, which have no hue, the hue of their neighbor, like BlendHcl.
+func (g *Gradient) unwrapHues() {
+	const gray = 0.00015
+	for i := range g.points {
+		if g.points[i][1] > gray {
+			continue
+		}
+		for j := 1; j < len(g.points); j++ {
+			if i-j >= 0 && g.points[i-j][1] > gray {
+				g.points[i][0] = g.points[i-j][0]
+				break
+			}
+			if i+j < len(g.points) && g.points[i+j][1] > gray {
+				g.points[i][0] = g.points[i+j][0]
+				break
+			}
+		}
+	}
+	for i := 1; i < len(g.points); i++ {
+		g.points[i][0] = g.points[i-1][0] + math.Mod(math.Mod(g.points[i][0]-g.points[i-1][0], 360.0)+540.0, 360.0) - 180.0
+	}
+}
+
+// naturalSpline computes the second derivatives of the natural cubic spline
+// through the stops lo to hi, which are zero at the ends, with the Thomas
+// algorithm for the tridiagonal system.
+func (g *Gradient) naturalSpline(lo, hi int) {
+	if g.second == nil {
+		g.second = make([][3]float64, len(g.stops))
+	}
+	n := hi - lo + 1
+	if n < 3 {
+		return
+	}
+
+	x := func(i int) float64 { return g.stops[lo+i].Pos }
+	cp := make([]float64, n)
+	dp := make([][3]float64, n)
+	for i := 1; i < n-1; i++ {
+		h0, h1 := x(i)-x(i-1), x(i+1)-x(i)
+		a, b, c := h0/6.0, (h0+h1)/3.0, h1/6.0
+		m := b - a*cp[i-1]
+		cp[i] = c / m
+		for k := 0; k < 3; k++ {
+			d := (g.points[lo+i+1][k]-g.points[lo+i][k])/h1 - (g.points[lo+i][k]-g.points[lo+i-1][k])/h0
+			dp[i][k] = (d - a*dp[i-1][k]) / m
+		}
+	}
+	for i := n - 2; i >= 1; i-- {
+		for k := 0; k < 3; k++ {
+			g.second[lo+i][k] = dp[i][k] - cp[i]*g.second[lo+i+1][k]
+		}
+	}
+}
+
+// splineAt evaluates the spline between stop i and i+1 at t in between.
+func (g *Gradient) splineAt(i int, t float64) (p [3]float64) {
+	x0, x1 := g.stops[i].Pos, g.stops[i+1].Pos
+	h := x1 - x0
+	s := (t - x0) / h
+	lo, hi := g.runStart[i], g.runEnd[i]
+
+	switch g.interp {
+	case InterpolationCatmullRom:
+		// The tangents are the finite differences to the neighbors, one-sided at the ends.
+		tangent := func(j int) (m [3]float64) {
+			a, b := j-1, j+1
+			if j == lo {
+				a = j
+			}
+			if j == hi {
+				b = j
+			}
+			for k := range m {
+				m[k] = (g.points[b][k] - g.points[a][k]) / (g.stops[b].Pos - g.stops[a].Pos)
+			}
+			return
+		}
+		m0, m1 := tangent(i), tangent(i+1)
+		s2, s3 := s*s, s*s*s
+		h00, h10, h01, h11 := 2*s3-3*s2+1, s3-2*s2+s, -2*s3+3*s2, s3-s2
+		for k := range p {
+			p[k] = h00*g.points[i][k] + h10*h*m0[k] + h01*g.points[i+1][k] + h11*h*m1[k]
+		}
+
+	case InterpolationNatural:
+		m0, m1 := g.second[i], g.second[i+1]
+		for k := range p {
+			p[k] = m0[k]*cub(x1-t)/(6*h) + m1[k]*cub(t-x0)/(6*h) +
+				(g.points[i][k]/h-m0[k]*h/6)*(x1-t) + (g.points[i+1][k]/h-m1[k]*h/6)*(t-x0)
+		}
+
+	case InterpolationBSpline:
+		// Mirrored control points before and after the run make it pass
+		// through the first and last stop.
+		ctrl := func(j int) [3]float64 {
+			a, b := j, j
+			switch {
+			case j < lo:
+				a, b = lo, lo+1
+			case j > hi:
+				a, b = hi, hi-1
+			default:
+				return g.points[j]
+			}
+			return [3]float64{2*g.points[a][0] - g.points[b][0], 2*g.points[a][1] - g.points[b][1], 2*g.points[a][2] - g.points[b][2]}
+		}
+		c0, c1, c2, c3 := ctrl(i-1), ctrl(i), ctrl(i+1), ctrl(i+2)
+		b0, b1, b2, b3 := cub(1-s), 3*cub(s)-6*s*s+4, -3*cub(s)+3*s*s+3*s+1, cub(s)
+		for k := range p {
+			p[k] = (b0*c0[k] + b1*c1[k] + b2*c2[k] + b3*c3[k]) / 6
+		}
+	}
+	return
+}
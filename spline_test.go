@@ -0,0 +1,204 @@
+package colorful
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func mustGradientEx(t *testing.T, settings GradientSettings, stops ...GradientStop) Gradient {
+	g, err := NewGradientEx(settings, stops...)
+	if err != nil {
+		t.Fatalf("NewGradientEx(%v) => %v", settings, err)
+	}
+	return g
+}
+
+var splineStops = []GradientStop{
+	{Color{0.0, 0.0, 0.2}, 0.0},
+	{Color{0.6, 0.1, 0.4}, 0.3},
+	{Color{0.9, 0.5, 0.2}, 0.6},
+	{Color{1.0, 1.0, 0.8}, 1.0},
+}
+
+func TestSplineThroughStops(t *testing.T) {
+	for _, space := range []GradientSpace{GradientLab, GradientOkLab, GradientHcl} {
+		for _, interp := range []GradientInterpolation{InterpolationCatmullRom, InterpolationNatural, InterpolationBSpline} {
+			g := mustGradientEx(t, GradientSettings{Space: space, Interpolation: interp}, splineStops...)
+			for i, s := range splineStops {
+				// The B-spline only goes through the first and last stop.
+				if interp == InterpolationBSpline && i != 0 && i != len(splineStops)-1 {
+					continue
+				}
+				if c := g.At(s.Pos); !c.AlmostEqualRgb(s.Color) {
+					t.Errorf("%v %v gradient At(%v) => %v, want %v", space, interp, s.Pos, c, s.Color)
+				}
+			}
+		}
+	}
+}
+
+func TestSplineTwoStops(t *testing.T) {
+	// With just two stops, all the splines are straight lines.
+	c1, c2 := Color{0.9, 0.2, 0.1}, Color{0.1, 0.3, 0.8}
+	for _, interp := range []GradientInterpolation{InterpolationCatmullRom, InterpolationNatural, InterpolationBSpline} {
+		g := mustGradientEx(t, GradientSettings{Space: GradientLab, Interpolation: interp}, GradientStop{c1, 0}, GradientStop{c2, 1})
+		for _, tt := range []float64{0.1, 0.3, 0.5, 0.8} {
+			if c, want := g.At(tt), c1.BlendLab(c2, tt).Clamped(); !c.AlmostEqualRgb(want) {
+				t.Errorf("%v gradient At(%v) => %v, want %v", interp, tt, c, want)
+			}
+		}
+	}
+}
+
+func TestSplineSmooth(t *testing.T) {
+	// The slope on both sides of a stop is the same, where the linear gradient has a kink.
+	slopes := func(g Gradient, pos float64) (left, right float64) {
+		const h = 1e-4
+		l0, _, _ := g.At(pos - h).OkLab()
+		l1, _, _ := g.At(pos).OkLab()
+		l2, _, _ := g.At(pos + h).OkLab()
+		return (l1 - l0) / h, (l2 - l1) / h
+	}
+
+	for _, interp := range []GradientInterpolation{InterpolationCatmullRom, InterpolationNatural, InterpolationBSpline} {
+		g := mustGradientEx(t, GradientSettings{Space: GradientOkLab, Interpolation: interp}, splineStops...)
+		if left, right := slopes(g, 0.3); math.Abs(left-right) > 0.01 {
+			t.Errorf("%v gradient has a kink at 0.3: slopes %v and %v", interp, left, right)
+		}
+	}
+
+	g := mustGradientEx(t, GradientSettings{Space: GradientOkLab}, splineStops...)
+	if left, right := slopes(g, 0.3); math.Abs(left-right) < 0.1 {
+		t.Errorf("linear gradient has no kink at 0.3: slopes %v and %v", left, right)
+	}
+}
+
+func TestSplineNaturalEnds(t *testing.T) {
+	g := mustGradientEx(t, GradientSettings{Space: GradientOkLab, Interpolation: InterpolationNatural}, splineStops...)
+	for _, i := range []int{0, len(splineStops) - 1} {
+		if g.second[i] != ([3]float64{}) {
+			t.Errorf("second derivative at stop %v => %v, want 0", i, g.second[i])
+		}
+	}
+	if g.second[1] == ([3]float64{}) {
+		t.Errorf("second derivative at stop 1 is 0")
+	}
+}
+
+func TestSplineHardStop(t *testing.T) {
+	red, white, blue, black := Color{1, 0, 0}, Color{1, 1, 1}, Color{0, 0, 1}, Color{0, 0, 0}
+	for _, interp := range []GradientInterpolation{InterpolationCatmullRom, InterpolationNatural, InterpolationBSpline} {
+		g := mustGradientEx(t, GradientSettings{Space: GradientLab, Interpolation: interp},
+			GradientStop{red, 0}, GradientStop{white, 0.5}, GradientStop{blue, 0.5}, GradientStop{black, 1})
+
+		// Each side is a run of its own, which is a straight line between two stops.
+		if c, want := g.At(0.25), red.BlendLab(white, 0.5).Clamped(); !c.AlmostEqualRgb(want) {
+			t.Errorf("%v gradient At(0.25) => %v, want %v", interp, c, want)
+		}
+		if c, want := g.At(0.75), blue.BlendLab(black, 0.5).Clamped(); !c.AlmostEqualRgb(want) {
+			t.Errorf("%v gradient At(0.75) => %v, want %v", interp, c, want)
+		}
+		if c := g.At(0.5); !c.AlmostEqualRgb(blue) {
+			t.Errorf("%v gradient At(0.5) => %v, want %v", interp, c, blue)
+		}
+	}
+}
+
+func TestSplineCorrectLightness(t *testing.T) {
+	stops := []GradientStop{
+		{mustHex("#00429d"), 0.0},
+		{mustHex("#96ffea"), 0.3},
+		{mustHex("#ffffe0"), 1.0},
+	}
+	for _, interp := range []GradientInterpolation{InterpolationLinear, InterpolationCatmullRom, InterpolationNatural, InterpolationBSpline} {
+		g := mustGradientEx(t, GradientSettings{Space: GradientLab, Interpolation: interp, CorrectLightness: true}, stops...)
+		lfirst, _, _ := stops[0].Color.Lab()
+		llast, _, _ := stops[len(stops)-1].Color.Lab()
+		for i := 0; i <= 20; i++ {
+			tt := float64(i) / 20.0
+			want := lfirst + tt*(llast-lfirst)
+			// Clamping colors out of gamut changes their lightness a bit.
+			if l, _, _ := g.At(tt).Lab(); math.Abs(l-want) > 0.02 {
+				t.Errorf("%v gradient At(%v) has lightness %v, want %v", interp, tt, l, want)
+			}
+		}
+	}
+
+	// Without correction, the lightness jumps up to the second stop.
+	g := mustGradientEx(t, GradientSettings{Space: GradientLab}, stops...)
+	if l, _, _ := g.At(0.3).Lab(); l < 0.9 {
+		t.Errorf("uncorrected gradient At(0.3) has lightness %v", l)
+	}
+}
+
+func TestSplineHcl(t *testing.T) {
+	// The hue goes the short way from 350 over 0 to 10, not back through 180.
+	c1, c2, c3 := Hcl(350, 0.3, 0.5), Hcl(0, 0.3, 0.6), Hcl(10, 0.3, 0.5)
+	g := mustGradientEx(t, GradientSettings{Space: GradientHcl, Interpolation: InterpolationCatmullRom},
+		GradientStop{c1, 0}, GradientStop{c2, 0.5}, GradientStop{c3, 1})
+	for _, tt := range []float64{0.1, 0.25, 0.75, 0.9} {
+		if h, _, _ := g.At(tt).Hcl(); 15 < h && h < 345 {
+			t.Errorf("At(%v) has hue %v", tt, h)
+		}
+	}
+
+	// A gray stop takes the hue of its neighbor.
+	g = mustGradientEx(t, GradientSettings{Space: GradientHcl, Interpolation: InterpolationNatural},
+		GradientStop{Color{0.5, 0.5, 0.5}, 0}, GradientStop{c1, 0.5}, GradientStop{c3, 1})
+	if h, _, _ := g.At(0.25).Hcl(); 15 < h && h < 340 {
+		t.Errorf("At(0.25) has hue %v", h)
+	}
+}
+
+func TestSplineValidation(t *testing.T) {
+	red := Color{1, 0, 0}
+	if _, err := NewGradientEx(GradientSettings{Space: GradientRgb, Interpolation: InterpolationCatmullRom}, GradientStop{red, 0}); err == nil {
+		t.Errorf("NewGradientEx with a spline in rgb didn't fail")
+	}
+	if _, err := NewGradientEx(GradientSettings{Space: GradientOkLch, CorrectLightness: true}, GradientStop{red, 0}); err == nil {
+		t.Errorf("NewGradientEx with lightness correction in oklch didn't fail")
+	}
+	if _, err := NewGradientEx(GradientSettings{Space: GradientLab, Interpolation: GradientInterpolation(42)}, GradientStop{red, 0}); err == nil {
+		t.Errorf("NewGradientEx with an unknown interpolation didn't fail")
+	}
+	for _, interp := range []GradientInterpolation{InterpolationLinear, InterpolationCatmullRom, InterpolationNatural, InterpolationBSpline} {
+		if gi, err := ParseGradientInterpolation(interp.String()); gi != interp || err != nil {
+			t.Errorf("ParseGradientInterpolation(%q) => (%v, %v), want %v", interp.String(), gi, err, interp)
+		}
+	}
+	if _, err := ParseGradientInterpolation("bezier"); err == nil {
+		t.Errorf("ParseGradientInterpolation(\"bezier\") didn't fail")
+	}
+}
+
+func TestSplineJson(t *testing.T) {
+	settings := GradientSettings{Space: GradientOkLab, Interpolation: InterpolationNatural, CorrectLightness: true}
+	g := mustGradientEx(t, settings, GradientStop{mustHex("#00429d"), 0}, GradientStop{mustHex("#ffffe0"), 0.5}, GradientStop{mustHex("#93003a"), 1})
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) => %v", g, err)
+	}
+	want := `{"space":"oklab","interpolation":"natural","correct_lightness":true,"stops":[{"color":"#00429d","pos":0},{"color":"#ffffe0","pos":0.5},{"color":"#93003a","pos":1}]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal => %s, want %s", data, want)
+	}
+
+	var g2 Gradient
+	if err := json.Unmarshal(data, &g2); err != nil {
+		t.Fatalf("json.Unmarshal(%s) => %v", data, err)
+	}
+	if !reflect.DeepEqual(g2, g) || g2.Settings() != settings {
+		t.Errorf("json.Unmarshal(json.Marshal(g)) => %v, want %v", g2, g)
+	}
+
+	for _, bad := range []string{
+		`{"space":"oklab","interpolation":"bezier","stops":[{"color":"#00429d","pos":0}]}`,
+		`{"space":"rgb","interpolation":"natural","stops":[{"color":"#00429d","pos":0}]}`,
+	} {
+		if err := json.Unmarshal([]byte(bad), &g2); err == nil {
+			t.Errorf("json.Unmarshal(%s) didn't fail", bad)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package colorful
+
+// A TonalPalette is a deterministic palette in the style of Material Design:
+// all of its colors share the same HCT hue and chroma, and only differ in tone.
+// This makes it easy to pick colors with a guaranteed contrast between them.
+type TonalPalette struct {
+	Hue, Chroma float64
+}
+
+// NewTonalPalette creates the tonal palette of the given key color's hue and chroma.
+func NewTonalPalette(key Color) TonalPalette {
+	h, c, _ := key.Hct()
+	return TonalPalette{h, c}
+}
+
+// Tone returns the color of the palette at the given tone in [0..100].
+// If the palette's chroma is not available at that tone, the most chromatic
+// color is returned instead, so the result is always a valid color.
+func (tp TonalPalette) Tone(t float64) Color {
+	return Hct(tp.Hue, tp.Chroma, t)
+}
+
+// Tones returns the colors of the palette at the given tones.
+func (tp TonalPalette) Tones(tones ...float64) []Color {
+	colors := make([]Color, len(tones))
+	for i, t := range tones {
+		colors[i] = tp.Tone(t)
+	}
+	return colors
+}
@@ -0,0 +1,195 @@
+package colorful
+
+import "math"
+
+// Spectral upsampling finds a plausible, smooth reflectance spectrum for a
+// color, for use in spectral renderers or for mixing paints. Many different
+// spectra map to the same color (metamers), these methods only produce one.
+// All spectra are for illuminant D65 and the CIE 1931 2° observer, the
+// conditions which the sRGB colors of this library are defined for.
+
+/// Smits ///
+/////////////
+// Brian Smits, "An RGB-to-spectrum conversion for reflectances", 1999.
+
+// Smits' basis spectra in 10 bins from 380 nm to 720 nm.
+var (
+	smitsWhite   = []float64{1.0000, 1.0000, 0.9999, 0.9993, 0.9992, 0.9998, 1.0000, 1.0000, 1.0000, 1.0000}
+	smitsCyan    = []float64{0.9710, 0.9426, 1.0007, 1.0007, 1.0007, 1.0007, 0.1564, 0.0000, 0.0000, 0.0000}
+	smitsMagenta = []float64{1.0000, 1.0000, 0.9685, 0.2229, 0.0000, 0.0458, 0.8369, 1.0000, 1.0000, 0.9959}
+	smitsYellow  = []float64{0.0001, 0.0000, 0.1088, 0.6651, 1.0000, 1.0000, 0.9996, 0.9586, 0.9685, 0.9840}
+	smitsRed     = []float64{0.1012, 0.0515, 0.0000, 0.0000, 0.0000, 0.0000, 0.8325, 1.0149, 1.0149, 1.0149}
+	smitsGreen   = []float64{0.0000, 0.0000, 0.0273, 0.7937, 1.0000, 0.9418, 0.1719, 0.0000, 0.0000, 0.0025}
+	smitsBlue    = []float64{1.0000, 1.0000, 0.8916, 0.3323, 0.0000, 0.0000, 0.0003, 0.0369, 0.0483, 0.0496}
+)
+
+// ReflectanceSmits returns a reflectance spectrum for the color using Smits'
+// method, which adds up coarse basis spectra. It is fast, but only
+// round-trips approximately: pastel colors come back within a few percent,
+// saturated ones can be far off, because the basis spectra were not designed
+// for the sRGB primaries. Use Reflectance for anything that needs to match
+// the color.
+// The color is clamped to the sRGB gamut first.
+func (col Color) ReflectanceSmits() Spectrum {
+	r, g, b := col.Clamped().LinearRgb()
+
+	values := make([]float64, len(smitsWhite))
+	add := func(w float64, basis []float64) {
+		for i := range values {
+			values[i] += w * basis[i]
+		}
+	}
+
+	// Take out as much white as possible, then as much of the secondary
+	// color, and the rest is the primary color.
+	if r <= g && r <= b {
+		add(r, smitsWhite)
+		if g <= b {
+			add(g-r, smitsCyan)
+			add(b-g, smitsBlue)
+		} else {
+			add(b-r, smitsCyan)
+			add(g-b, smitsGreen)
+		}
+	} else if g <= r && g <= b {
+		add(g, smitsWhite)
+		if r <= b {
+			add(r-g, smitsMagenta)
+			add(b-r, smitsBlue)
+		} else {
+			add(b-g, smitsMagenta)
+			add(r-b, smitsRed)
+		}
+	} else {
+		add(b, smitsWhite)
+		if r <= g {
+			add(r-b, smitsYellow)
+			add(g-r, smitsGreen)
+		} else {
+			add(g-b, smitsYellow)
+			add(r-g, smitsRed)
+		}
+	}
+
+	// The bins are 34 nm wide, sample them at their centers.
+	return NewSpectrum(380.0+17.0, 34.0, values)
+}
+
+/// Jakob-Hanika ///
+////////////////////
+// Wenzel Jakob and Johannes Hanika, "A Low-Dimensional Function Space for
+// Efficient Spectral Upsampling", Computer Graphics Forum 38(2), 2019.
+//
+// The reflectance is modeled as a sigmoid of a quadratic polynomial, which is
+// smooth and always within [0..1]. Instead of their precomputed table, the
+// three coefficients are fitted on the fly with Gauss-Newton.
+
+// The CIE 1931 color matching functions weighted by D65, normalized to Y = 1 for a perfect white.
+var jakobHanikaWeights = func() (w [len(cie1931Cmf)][3]float64) {
+	k := 0.0
+	for i, c := range cie1931Cmf {
+		k += d65Spd[i] * c[1]
+	}
+	for i, c := range cie1931Cmf {
+		for j := range c {
+			w[i][j] = d65Spd[i] * c[j] / k
+		}
+	}
+	return
+}()
+
+func jakobHanikaSigmoid(x float64) float64 {
+	return 0.5 + x/(2.0*math.Sqrt(1.0+x*x))
+}
+
+// jakobHanikaValue evaluates the sigmoid polynomial at the i-th sample; the
+// wavelength is mapped to [0..1] to keep the coefficients well-conditioned.
+func jakobHanikaValue(c [3]float64, i int) float64 {
+	x := float64(i) / float64(len(cie1931Cmf)-1)
+	return jakobHanikaSigmoid((c[0]*x+c[1])*x + c[2])
+}
+
+func jakobHanikaLab(c [3]float64) (l, a, b float64) {
+	var x, y, z float64
+	for i, w := range jakobHanikaWeights {
+		r := jakobHanikaValue(c, i)
+		x += r * w[0]
+		y += r * w[1]
+		z += r * w[2]
+	}
+	return XyzToLab(x, y, z)
+}
+
+// jakobHanikaFit runs Gauss-Newton from the given coefficients towards the
+// target Lab color, and returns the coefficients and remaining error.
+func jakobHanikaFit(c [3]float64, target [3]float64) ([3]float64, float64) {
+	const h = 1e-6
+	residual := func(c [3]float64) (r [3]float64) {
+		l, a, b := jakobHanikaLab(c)
+		return [3]float64{l - target[0], a - target[1], b - target[2]}
+	}
+
+	r := residual(c)
+	err := math.Sqrt(sq(r[0]) + sq(r[1]) + sq(r[2]))
+	for iter := 0; iter < 50 && err > 1e-7; iter++ {
+		// Jacobian by forward differences.
+		var jac [3][3]float64
+		for j := 0; j < 3; j++ {
+			cj := c
+			cj[j] += h
+			rj := residual(cj)
+			for i := 0; i < 3; i++ {
+				jac[i][j] = (rj[i] - r[i]) / h
+			}
+		}
+		d0, d1, d2 := mat3Apply(mat3Inv(jac), r[0], r[1], r[2])
+		if math.IsNaN(d0) || math.IsNaN(d1) || math.IsNaN(d2) {
+			break
+		}
+
+		// Halve the step until it improves, so we don't overshoot into the flat tails of the sigmoid.
+		step := 1.0
+		for ; step > 1e-4; step *= 0.5 {
+			cn := [3]float64{c[0] - step*d0, c[1] - step*d1, c[2] - step*d2}
+			rn := residual(cn)
+			if errn := math.Sqrt(sq(rn[0]) + sq(rn[1]) + sq(rn[2])); errn < err {
+				c, r, err = cn, rn, errn
+				break
+			}
+		}
+		if step <= 1e-4 {
+			break
+		}
+	}
+	return c, err
+}
+
+// Reflectance returns a smooth reflectance spectrum for the color, using the
+// sigmoid-polynomial method of Jakob and Hanika. Integrating the spectrum
+// under SpectrumD65 gives back the color, i.e.
+// Xyz(col.Reflectance().Xyz(SpectrumD65)) is col within Delta.
+// The color is clamped to the sRGB gamut first.
+func (col Color) Reflectance() Spectrum {
+	l, a, b := col.Clamped().Lab()
+	target := [3]float64{l, a, b}
+
+	// Fitting the target directly fails for very saturated colors, so walk
+	// there from neutral gray in increasingly large steps, always starting
+	// from the previous solution, like Jakob and Hanika fill their table.
+	var c [3]float64
+	c, err := jakobHanikaFit(c, target)
+	for steps := 2; err > 1e-6 && steps <= 64; steps *= 2 {
+		c = [3]float64{}
+		gl, _, _ := jakobHanikaLab(c)
+		for s := 1; s <= steps; s++ {
+			t := float64(s) / float64(steps)
+			c, err = jakobHanikaFit(c, [3]float64{gl + t*(l-gl), t * a, t * b})
+		}
+	}
+
+	values := make([]float64, len(cie1931Cmf))
+	for i := range values {
+		values[i] = jakobHanikaValue(c, i)
+	}
+	return NewSpectrum(380, 5, values)
+}
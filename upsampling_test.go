@@ -0,0 +1,58 @@
+package colorful
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReflectanceRoundtrip(t *testing.T) {
+	for r := 0.0; r <= 1.0; r += 0.125 {
+		for g := 0.0; g <= 1.0; g += 0.125 {
+			for b := 0.0; b <= 1.0; b += 0.125 {
+				col := Color{r, g, b}
+				if c := Xyz(col.Reflectance().Xyz(SpectrumD65)); !c.AlmostEqualRgb(col) {
+					t.Errorf("Xyz(%v.Reflectance().Xyz(SpectrumD65)) => %v, want %v (delta %v)", col, c, col, delta)
+				}
+			}
+		}
+	}
+}
+
+func TestReflectanceRange(t *testing.T) {
+	for i, tt := range vals {
+		s := tt.c.Reflectance()
+		for j, v := range s.Values {
+			if v < 0.0 || v > 1.0 {
+				t.Errorf("%v. %v.Reflectance() at %vnm => %v, want in [0..1]", i, tt.c, s.Wavelengths[j], v)
+			}
+		}
+	}
+
+	// A red surface reflects long, and absorbs short wavelengths.
+	s := Color{0.8, 0.1, 0.1}.Reflectance()
+	if s.At(650) < 0.5 || s.At(450) > 0.1 {
+		t.Errorf("Reflectance of red at 450nm => %v, at 650nm => %v", s.At(450), s.At(650))
+	}
+}
+
+func TestReflectanceSmits(t *testing.T) {
+	// Unsaturated colors come back closely.
+	for i, tt := range []Color{{0.5, 0.5, 0.5}, {0.6, 0.5, 0.4}, {0.3, 0.4, 0.5}, {0.8, 0.7, 0.75}, {1, 1, 1}} {
+		if d := Xyz(tt.ReflectanceSmits().Xyz(SpectrumD65)).DistanceLab(tt); d > 0.02 {
+			t.Errorf("%v. Xyz(%v.ReflectanceSmits().Xyz(SpectrumD65)) is %v away in Lab", i, tt, d)
+		}
+	}
+
+	// Saturated colors at least keep their hue.
+	for i, tt := range []Color{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 0}} {
+		h1, _, _ := tt.Hcl()
+		h2, _, _ := Xyz(tt.ReflectanceSmits().Xyz(SpectrumD65)).Hcl()
+		if dh := math.Abs(h1 - h2); math.Min(dh, 360-dh) > 30 {
+			t.Errorf("%v. Hue of %v.ReflectanceSmits() => %v, want %v", i, tt, h2, h1)
+		}
+	}
+
+	if s := (Color{0, 0, 0}).ReflectanceSmits(); s.At(550) != 0 {
+		t.Errorf("Black.ReflectanceSmits() at 550nm => %v, want 0", s.At(550))
+	}
+}